@@ -45,12 +45,17 @@ func main() {
 
 	logger.Infof("Client run with config: %s", cfg.PrintConfig())
 
-	grpcClient, err := clientApp.NewGRPCClient(cfg.Host, cfg.RootCertPath)
+	grpcClient, err := clientApp.NewGRPCClient(cfg.Host, cfg.RootCertPath, cfg.ClientCertPath, cfg.ClientKeyPath, buildVersion)
 	if err != nil {
 		logger.Fatal(err)
 	}
 
-	cliClient := cli.NewClientCLI(grpcClient, logger)
+	cachePassphrase, err := cfg.GetCachePassphrase()
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	cliClient := cli.NewClientCLI(grpcClient, cfg.GetDeviceName(), cfg.GetCachePath(), cachePassphrase, logger)
 	err = cliClient.Run()
 	if err != nil {
 		logger.Info(err)
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -47,11 +48,28 @@ func main() {
 
 	logger.Infof("Server run with config: %s", cfg.PrintConfig())
 
+	if id, _ := cfg.GetKeyringAdd(); id != "" {
+		err = server.AddKeyringKey(cfg)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		logger.Infof("key %s added to %s and made active", id, cfg.GetKeyringPath())
+		return
+	}
+
 	srv, err := server.NewGRPCServer(cfg, logger)
 	if err != nil {
 		logger.Fatal(err)
 	}
 
+	if cfg.GetReencrypt() {
+		err = srv.RunReencryption(context.Background())
+		if err != nil {
+			logger.Fatal(err)
+		}
+		return
+	}
+
 	err = srv.RunGRPCServer()
 	if err != nil {
 		logger.Fatal(err)
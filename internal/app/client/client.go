@@ -1,25 +1,37 @@
 package client
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
+
 	"google.golang.org/grpc/credentials"
 
 	pb "github.com/bbquite/go-pass-keeper/internal/proto"
 	"google.golang.org/grpc"
 )
 
+const userAgentPrefix = "go-pass-keeper-cli/"
+
 type GRPCClient struct {
 	conn      *grpc.ClientConn
+	certAuth  bool
 	PBService pb.PassKeeperServiceClient
 }
 
-func NewGRPCClient(serverAddress string, rootCertPath string) (*GRPCClient, error) {
-	TLScreeds, err := credentials.NewClientTLSFromFile(rootCertPath, "")
+// NewGRPCClient connects to the server over TLS. When clientCertPath and
+// clientKeyPath are set, the client certificate is presented for mTLS and can
+// be used instead of a password login.
+func NewGRPCClient(serverAddress string, rootCertPath string, clientCertPath string, clientKeyPath string, version string) (*GRPCClient, error) {
+	TLScreeds, err := loadTLSCredentials(rootCertPath, clientCertPath, clientKeyPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load CA certificate: %v", err)
+		return nil, err
 	}
 
-	conn, err := grpc.NewClient(serverAddress, grpc.WithTransportCredentials(TLScreeds))
+	conn, err := grpc.NewClient(serverAddress,
+		grpc.WithTransportCredentials(TLScreeds),
+		grpc.WithUserAgent(userAgentPrefix+version))
 	if err != nil {
 		return nil, fmt.Errorf("error init gRPC client: %v", err)
 	}
@@ -28,10 +40,51 @@ func NewGRPCClient(serverAddress string, rootCertPath string) (*GRPCClient, erro
 
 	return &GRPCClient{
 		conn:      conn,
+		certAuth:  clientCertPath != "",
 		PBService: pbService,
 	}, nil
 }
 
+func loadTLSCredentials(rootCertPath string, clientCertPath string, clientKeyPath string) (credentials.TransportCredentials, error) {
+	if clientCertPath == "" && clientKeyPath == "" {
+		TLScreeds, err := credentials.NewClientTLSFromFile(rootCertPath, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CA certificate: %v", err)
+		}
+		return TLScreeds, nil
+	}
+
+	if clientCertPath == "" || clientKeyPath == "" {
+		return nil, fmt.Errorf("client certificate and key must be set together")
+	}
+
+	caPEM, err := os.ReadFile(rootCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CA certificate: %v", err)
+	}
+
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("failed to load CA certificate: no certificates in %s", rootCertPath)
+	}
+
+	clientCert, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %v", err)
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		RootCAs:      rootCAs,
+		Certificates: []tls.Certificate{clientCert},
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// HasClientCertificate reports whether the connection presents a client certificate.
+func (c *GRPCClient) HasClientCertificate() bool {
+	return c.certAuth
+}
+
 func (c *GRPCClient) Close() error {
 	err := c.conn.Close()
 	if err != nil {
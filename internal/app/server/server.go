@@ -1,6 +1,10 @@
 package server
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -16,6 +20,8 @@ import (
 
 	"github.com/bbquite/go-pass-keeper/internal/config"
 	"github.com/bbquite/go-pass-keeper/internal/handlers"
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	serverServices "github.com/bbquite/go-pass-keeper/internal/service/server"
 	"github.com/bbquite/go-pass-keeper/internal/storage/postgres"
 
 	pb "github.com/bbquite/go-pass-keeper/internal/proto"
@@ -24,14 +30,24 @@ import (
 	"google.golang.org/grpc/reflection"
 )
 
+// trashPurgeInterval is how often records past the trash retention are purged.
+const trashPurgeInterval = time.Hour
+
 type gRPCServer struct {
-	cfg           *config.ServerConfig
-	dbStorage     *postgres.DBStorage
-	handler       *handlers.GRPCHandler
-	interceptors  []grpc.UnaryServerInterceptor
-	jwtManager    *jwttoken.JWTManager
-	noAuthMethods []string
-	logger        *zap.SugaredLogger
+	cfg                *config.ServerConfig
+	dbStorage          *postgres.DBStorage
+	handler            *handlers.GRPCHandler
+	interceptors       []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+	jwtManager         *jwttoken.JWTManager
+	revocationService  *serverServices.RevocationService
+	accessTokenService *serverServices.AccessTokenService
+	keyRotationService *serverServices.KeyRotationService
+	trashPurger        *serverServices.TrashPurger
+	certAuthenticator  *serverServices.CertificateAuthenticator
+	noAuthMethods      []string
+	adminMethods       []string
+	logger             *zap.SugaredLogger
 }
 
 func NewGRPCServer(cfg *config.ServerConfig, logger *zap.SugaredLogger) (*gRPCServer, error) {
@@ -41,22 +57,84 @@ func NewGRPCServer(cfg *config.ServerConfig, logger *zap.SugaredLogger) (*gRPCSe
 		return nil, fmt.Errorf("database connection error: %v", err)
 	}
 
-	jwtManager := jwttoken.NewJWTTokenManager(time.Hour*3, cfg.JWTSecret)
-	encryptorManager := encryptor.NewEncryptor([]byte(cfg.CryptoKey))
-	handler := handlers.NewGRPCHandler(jwtManager, encryptorManager, dbStorage, logger)
+	keyring, err := loadJWTKeyring(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("jwt keyring init error: %v", err)
+	}
+
+	jwtManager := jwttoken.NewJWTTokenManager(keyring, cfg.GetJWTExpiry(), cfg.GetRefreshTokenTTL(), cfg.GetJWTIssuer(), cfg.GetJWTAudience())
+	encryptorManager, err := loadEncryptor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("crypto keys init error: %v", err)
+	}
+	passwordHasher, err := loadPasswordHasher(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("password hasher init error: %v", err)
+	}
+	passwordPolicy := &serverServices.PasswordPolicy{
+		MinLength:      cfg.GetPasswordMinLength(),
+		MinCharClasses: cfg.GetPasswordMinClasses(),
+		MinEntropyBits: cfg.GetPasswordMinEntropy(),
+	}
+	if cfg.GetBreachedPasswordsPath() != "" {
+		passwordPolicy.Breached, err = serverServices.NewBreachedPasswordCorpus(cfg.GetBreachedPasswordsPath())
+		if err != nil {
+			return nil, fmt.Errorf("breached passwords init error: %v", err)
+		}
+	}
+	revocationService := serverServices.NewRevocationService(dbStorage, logger)
+	loginLimiter := serverServices.NewLoginLimiter(dbStorage, serverServices.LoginLimiterPolicy{
+		MaxAttempts:   cfg.GetLoginMaxAttempts(),
+		IPMaxAttempts: cfg.GetLoginIPMaxAttempts(),
+		BaseDelay:     cfg.GetLoginBaseDelay(),
+		Lockout:       cfg.GetLoginLockout(),
+		Window:        cfg.GetLoginWindow(),
+	}, logger)
+	auditService := serverServices.NewAuditService(dbStorage, logger)
+	accessTokenService := serverServices.NewAccessTokenService(dbStorage, auditService, logger)
+	dataKeyService := serverServices.NewDataKeyService(dbStorage, encryptorManager, logger)
+	keyRotationService := serverServices.NewKeyRotationService(dbStorage, encryptorManager, dataKeyService, logger)
+	err = keyRotationService.LoadStatus(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("key rotation status error: %v", err)
+	}
+	trashPurger := serverServices.NewTrashPurger(dbStorage, cfg.GetTrashRetention(), logger)
+	handler := handlers.NewGRPCHandler(jwtManager, encryptorManager, passwordHasher, passwordPolicy, revocationService, loginLimiter, accessTokenService, auditService, dataKeyService, keyRotationService, cfg.GetDataVersions(), dbStorage, logger)
+
+	var certAuthenticator *serverServices.CertificateAuthenticator
+	if cfg.GetClientCAPath() != "" {
+		certAuthenticator, err = serverServices.NewCertificateAuthenticator(dbStorage, cfg.GetClientCertIdentity(), logger)
+		if err != nil {
+			return nil, fmt.Errorf("client certificate auth init error: %v", err)
+		}
+	}
 
 	noAuthMethods := []string{
 		"/internal.proto.PassKeeperService/RegisterUser",
 		"/internal.proto.PassKeeperService/AuthUser",
+		"/internal.proto.PassKeeperService/RefreshToken",
+		"/internal.proto.PassKeeperService/CompleteMfaLogin",
 	}
 
+	adminMethods := []string{
+		"/internal.proto.PassKeeperService/AdminUnlockLogin",
+		"/internal.proto.PassKeeperService/AdminReencryptData",
+	}
+	noAuthMethods = append(noAuthMethods, adminMethods...)
+
 	serverInit := &gRPCServer{
-		cfg:        cfg,
-		handler:    handler,
-		dbStorage:  dbStorage,
-		jwtManager: jwtManager,
+		cfg:                cfg,
+		handler:            handler,
+		dbStorage:          dbStorage,
+		jwtManager:         jwtManager,
+		revocationService:  revocationService,
+		accessTokenService: accessTokenService,
+		keyRotationService: keyRotationService,
+		trashPurger:        trashPurger,
+		certAuthenticator:  certAuthenticator,
 
 		noAuthMethods: noAuthMethods,
+		adminMethods:  adminMethods,
 		logger:        logger.Named("SERVER"),
 	}
 
@@ -68,13 +146,226 @@ func NewGRPCServer(cfg *config.ServerConfig, logger *zap.SugaredLogger) (*gRPCSe
 	return serverInit, nil
 }
 
+// loadJWTKeyring builds the keyring from the PEM keys and the shared secret.
+// Without an explicit active key the first PEM key signs new tokens, falling
+// back to the secret when no PEM keys are configured.
+func loadJWTKeyring(cfg *config.ServerConfig) (*jwttoken.Keyring, error) {
+	var keys []jwttoken.SigningKey
+
+	keyFiles, err := cfg.GetJWTKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range keyFiles {
+		key, err := jwttoken.LoadPEMKey(item.ID, item.Path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if cfg.GetSecretKey() != "" {
+		keys = append(keys, jwttoken.NewHMACKey(jwttoken.SecretKeyID, []byte(cfg.GetSecretKey())))
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: set --secret-key or --jwt-keys", jwttoken.ErrNoSigningKey)
+	}
+
+	activeKey := cfg.GetJWTActiveKey()
+	if activeKey == "" {
+		activeKey = keys[0].ID
+	}
+
+	return jwttoken.NewKeyring(activeKey, keys...)
+}
+
+func loadPasswordHasher(cfg *config.ServerConfig) (*serverServices.Argon2idHasher, error) {
+	memory, err := cfg.GetArgon2Memory()
+	if err != nil {
+		return nil, err
+	}
+	iterations, err := cfg.GetArgon2Iterations()
+	if err != nil {
+		return nil, err
+	}
+	threads, err := cfg.GetArgon2Threads()
+	if err != nil {
+		return nil, err
+	}
+
+	return serverServices.NewArgon2idHasher(serverServices.Argon2Params{
+		Memory:      memory,
+		Iterations:  iterations,
+		Parallelism: threads,
+	})
+}
+
+// loadEncryptor builds the master key ring from the configured key provider.
+// Without an explicit active key the provider's choice or the first key
+// encrypts new data; key version "1" also opens data written before key
+// versions existed.
+func loadEncryptor(cfg *config.ServerConfig) (*encryptor.Encryptor, error) {
+	provider, err := loadKeyProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return encryptor.LoadEncryptor(context.Background(), provider, cfg.GetCryptoActiveKey())
+}
+
+func loadKeyProvider(cfg *config.ServerConfig) (encryptor.KeyProvider, error) {
+	switch cfg.GetKeyProvider() {
+	case config.KeyProviderConfig:
+		cryptoKeys, err := cfg.GetCryptoKeys()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(cryptoKeys) == 0 {
+			return nil, fmt.Errorf("set --crypto-key, --crypto-keys or another --key-provider")
+		}
+
+		var keyring encryptor.Keyring
+		for _, item := range cryptoKeys {
+			value, err := encryptor.DecodeKey(item.Value)
+			if err != nil {
+				return nil, fmt.Errorf("crypto key %q: %w", item.ID, err)
+			}
+			keyring.Keys = append(keyring.Keys, encryptor.Key{ID: item.ID, Value: value})
+		}
+		return &encryptor.StaticKeyProvider{Keyring: keyring}, nil
+
+	case config.KeyProviderEnv:
+		return encryptor.NewEnvKeyProvider(cfg.GetKeyEnv()), nil
+
+	case config.KeyProviderFile:
+		if cfg.GetKeyringPath() == "" {
+			return nil, fmt.Errorf("set --keyring for the file key provider")
+		}
+		passphrase, err := cfg.GetKeyringPassphrase()
+		if err != nil {
+			return nil, err
+		}
+		return encryptor.NewFileKeyProvider(cfg.GetKeyringPath(), passphrase), nil
+
+	case config.KeyProviderTransit:
+		token, err := cfg.GetTransitToken()
+		if err != nil {
+			return nil, err
+		}
+		wrappedKeys, err := cfg.GetTransitWrappedKeys()
+		if err != nil {
+			return nil, err
+		}
+		return encryptor.NewTransitKeyProvider(cfg.GetTransitAddress(), token, cfg.GetTransitMount(), cfg.GetTransitKey(), wrappedKeys), nil
+
+	default:
+		return nil, fmt.Errorf("unknown key provider %q", cfg.GetKeyProvider())
+	}
+}
+
+// AddKeyringKey adds a key to the keyring file, creating the file if needed,
+// and makes it the active key. Without a key value a random one is generated.
+// Stored data is moved to the new key with --reencrypt.
+func AddKeyringKey(cfg *config.ServerConfig) error {
+	if cfg.GetKeyringPath() == "" {
+		return fmt.Errorf("set --keyring to add a key")
+	}
+	passphrase, err := cfg.GetKeyringPassphrase()
+	if err != nil {
+		return err
+	}
+
+	keyring, err := encryptor.ReadKeyringFile(cfg.GetKeyringPath(), passphrase)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	id, value := cfg.GetKeyringAdd()
+	for _, item := range keyring.Keys {
+		if item.ID == id {
+			return fmt.Errorf("key %q already exists in the keyring", id)
+		}
+	}
+
+	var key []byte
+	if value == "" {
+		key, err = encryptor.GenerateKey()
+	} else {
+		key, err = encryptor.DecodeKey(value)
+	}
+	if err != nil {
+		return err
+	}
+
+	keyring.Keys = append(keyring.Keys, encryptor.Key{ID: id, Value: key})
+	keyring.ActiveID = id
+
+	return encryptor.SaveKeyringFile(cfg.GetKeyringPath(), passphrase, keyring)
+}
+
+// RunReencryption re-encrypts stored data with the active crypto key, logging
+// the progress, instead of serving requests.
+func (s *gRPCServer) RunReencryption(ctx context.Context) error {
+	return s.keyRotationService.Reencrypt(ctx, 0, false, func(rotation models.KeyRotation) error {
+		s.logger.Infof("re-encryption to key %s: %s %d/%d, updated %d, failed %d",
+			rotation.KeyID, rotation.Stage, rotation.Processed, rotation.Total, rotation.Updated, rotation.Failed)
+		return nil
+	})
+}
+
 func (s *gRPCServer) loadServerInterceptors() error {
+	adminInterceptor := interceptors.NewAdminInterceptor(s.cfg.GetAdminToken(), s.adminMethods)
+	authInterceptor := interceptors.NewAuthInterceptor(s.jwtManager, s.revocationService, s.accessTokenService, s.noAuthMethods)
+	if s.certAuthenticator != nil {
+		authInterceptor.EnableCertificateAuth(s.certAuthenticator)
+	}
+
 	var grpcServerInterceptors []grpc.UnaryServerInterceptor
-	grpcServerInterceptors = append(grpcServerInterceptors, interceptors.NewAuthInterceptor(s.jwtManager, s.noAuthMethods).Unary())
+	grpcServerInterceptors = append(grpcServerInterceptors, adminInterceptor.Unary())
+	grpcServerInterceptors = append(grpcServerInterceptors, authInterceptor.Unary())
 	s.interceptors = grpcServerInterceptors
+
+	var grpcStreamInterceptors []grpc.StreamServerInterceptor
+	grpcStreamInterceptors = append(grpcStreamInterceptors, adminInterceptor.Stream())
+	grpcStreamInterceptors = append(grpcStreamInterceptors, authInterceptor.Stream())
+	s.streamInterceptors = grpcStreamInterceptors
+
 	return nil
 }
 
+// loadTLSCredentials returns the server TLS credentials. With a client CA
+// configured, client certificates are requested and verified but stay
+// optional, so password based clients keep working.
+func (s *gRPCServer) loadTLSCredentials() (credentials.TransportCredentials, error) {
+	if s.cfg.GetClientCAPath() == "" {
+		return credentials.NewServerTLSFromFile(s.cfg.GetServerCrtPath(), s.cfg.GetServerKeyPath())
+	}
+
+	serverCert, err := tls.LoadX509KeyPair(s.cfg.GetServerCrtPath(), s.cfg.GetServerKeyPath())
+	if err != nil {
+		return nil, err
+	}
+
+	caPEM, err := os.ReadFile(s.cfg.GetClientCAPath())
+	if err != nil {
+		return nil, err
+	}
+
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in client CA %s", s.cfg.GetClientCAPath())
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
 func (s *gRPCServer) RunGRPCServer() error {
 
 	signalCh := make(chan os.Signal, 1)
@@ -85,14 +376,15 @@ func (s *gRPCServer) RunGRPCServer() error {
 		return fmt.Errorf("error occured while running gRPC server: %v", err)
 	}
 
-	grpcCredos, err := credentials.NewServerTLSFromFile(s.cfg.GetServerCrtPath(), s.cfg.GetServerKeyPath())
+	grpcCredos, err := s.loadTLSCredentials()
 	if err != nil {
 		return fmt.Errorf("failed to load TLS certificates: %v", err)
 	}
 
 	grpcServer := grpc.NewServer(
 		grpc.Creds(grpcCredos),
-		grpc.ChainUnaryInterceptor(s.interceptors...))
+		grpc.ChainUnaryInterceptor(s.interceptors...),
+		grpc.ChainStreamInterceptor(s.streamInterceptors...))
 	reflection.Register(grpcServer)
 	pb.RegisterPassKeeperServiceServer(grpcServer, s.handler)
 
@@ -103,9 +395,17 @@ func (s *gRPCServer) RunGRPCServer() error {
 		return nil
 	}()
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	if s.cfg.GetTrashRetention() > 0 {
+		go s.trashPurger.Run(jobsCtx, trashPurgeInterval)
+	}
+
 	sig := <-signalCh
 	s.logger.Info("Received signal: %v\n", sig)
 
+	stopJobs()
 	grpcServer.GracefulStop()
 	s.dbStorage.DB.Close()
 
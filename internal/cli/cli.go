@@ -18,8 +18,8 @@ type ClientCLI struct {
 	logger         *zap.SugaredLogger
 }
 
-func NewClientCLI(grpcClient *client.GRPCClient, logger *zap.SugaredLogger) *ClientCLI {
-	commandManager := commands.NewCommandManager(grpcClient, logger)
+func NewClientCLI(grpcClient *client.GRPCClient, deviceName string, cachePath string, cachePassphrase string, logger *zap.SugaredLogger) *ClientCLI {
+	commandManager := commands.NewCommandManager(grpcClient, deviceName, cachePath, cachePassphrase, logger)
 
 	return &ClientCLI{
 		commandManager: commandManager,
@@ -28,14 +28,13 @@ func NewClientCLI(grpcClient *client.GRPCClient, logger *zap.SugaredLogger) *Cli
 }
 
 func (cli *ClientCLI) Run() error {
-	var input string
 	scanner := bufio.NewScanner(os.Stdin)
 
 	for {
 		fmt.Print("Enter root commands: ")
 
 		scanner.Scan()
-		input = strings.ToUpper(scanner.Text())
+		input, args := parseInput(scanner.Text())
 
 		cmd, exists := cli.commandManager.CommandRoot[input]
 		if !exists {
@@ -43,7 +42,7 @@ func (cli *ClientCLI) Run() error {
 			continue
 		}
 
-		err := cli.exec(cmd, scanner)
+		err := cli.exec(cmd, args, scanner)
 		if err != nil {
 			if errors.Is(err, commands.ErrorGracefullyStop) {
 				return err
@@ -56,8 +55,14 @@ func (cli *ClientCLI) Run() error {
 	}
 }
 
-func (cli *ClientCLI) exec(cmd commands.Command, scanner *bufio.Scanner) error {
+func (cli *ClientCLI) exec(cmd commands.Command, args []string, scanner *bufio.Scanner) error {
 	if cmd.Subcommands == nil {
+		if cmd.ExecuteArgs != nil {
+			return cmd.ExecuteArgs(args)
+		}
+		if len(args) > 0 {
+			return commands.ErrorUnexpectedArgs
+		}
 		if cmd.Execute != nil {
 			err := cmd.Execute()
 			if err != nil {
@@ -68,20 +73,34 @@ func (cli *ClientCLI) exec(cmd commands.Command, scanner *bufio.Scanner) error {
 		return commands.ErrorNoExecution
 	}
 
+	if len(args) > 0 {
+		return commands.ErrorUnexpectedArgs
+	}
+
 	fmt.Printf("Enter one of: %s\n", strings.Join(cmd.GetSubCommandsNames(), " | "))
 
 	scanner.Scan()
-	input := strings.ToUpper(scanner.Text())
+	input, args := parseInput(scanner.Text())
 
 	cmd, exists := cmd.Subcommands[input]
 	if !exists {
 		return commands.ErrorUnknownCommand
 	}
 
-	err := cli.exec(cmd, scanner)
+	err := cli.exec(cmd, args, scanner)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// parseInput splits an input line into the command name, case-insensitive,
+// and its arguments, e.g. "SHOW --tag work".
+func parseInput(line string) (string, []string) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return strings.ToUpper(fields[0]), fields[1:]
+}
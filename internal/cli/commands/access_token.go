@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	clitable "github.com/bbquite/go-pass-keeper/pkg/table"
+)
+
+// accessTokenDataTypes maps the names accepted by the CLI to data types.
+var accessTokenDataTypes = map[string]models.DataTypeEnum{
+	"PAIR":   models.DataTypePAIR,
+	"TEXT":   models.DataTypeTEXT,
+	"FILE":   models.DataTypeBINARY,
+	"BINARY": models.DataTypeBINARY,
+	"CARD":   models.DataTypeCARD,
+}
+
+func (cm *CommandManager) createAccessTokenCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	days, err := strconv.ParseUint(paramsValidated["days"].value, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	scopes := models.AccessTokenScopes{
+		ReadOnly: strings.EqualFold(strings.TrimSpace(paramsValidated["read_only"].value), "y"),
+	}
+
+	for _, name := range splitList(paramsValidated["types"].value) {
+		scopeType, ok := accessTokenDataTypes[strings.ToUpper(name)]
+		if !ok {
+			return fmt.Errorf("unknown data type %q", name)
+		}
+		scopes.DataTypes = append(scopes.DataTypes, scopeType)
+	}
+
+	for _, value := range splitList(paramsValidated["ids"].value) {
+		recordID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid record ID %q", value)
+		}
+		scopes.RecordIDs = append(scopes.RecordIDs, uint32(recordID))
+	}
+
+	token, err := cm.authService.CreateAccessToken(context.Background(), paramsValidated["name"].value, uint32(days), scopes)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nAccess token (shown only once, store it safely):\n\n    %s\n\n", token)
+	fmt.Print("Send it as \"authorization: Bearer <token>\" metadata\n")
+
+	return nil
+}
+
+func (cm *CommandManager) listAccessTokensCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	accessTokens, err := cm.authService.ListAccessTokens(context.Background())
+	if err != nil {
+		return err
+	}
+
+	tokensTable := clitable.New([]string{"ID", "NAME", "READ ONLY", "TYPES", "RECORDS", "EXPIRES", "LAST USED"})
+	tokensTable.Markdown = true
+
+	for _, item := range accessTokens {
+		var types []string
+		for _, scopeType := range item.Scopes.DataTypes {
+			types = append(types, string(scopeType))
+		}
+
+		var records []string
+		for _, recordID := range item.Scopes.RecordIDs {
+			records = append(records, strconv.FormatUint(uint64(recordID), 10))
+		}
+
+		lastUsed := ""
+		if item.LastUsedAt != nil {
+			lastUsed = item.LastUsedAt.Format(displayTimeLayout)
+		}
+
+		tokensTable.AddRow(map[string]interface{}{
+			"ID":        item.ID,
+			"NAME":      item.Name,
+			"READ ONLY": item.Scopes.ReadOnly,
+			"TYPES":     orAll(strings.Join(types, ",")),
+			"RECORDS":   orAll(strings.Join(records, ",")),
+			"EXPIRES":   item.ExpiresAt.Format(displayTimeLayout),
+			"LAST USED": lastUsed,
+		})
+	}
+
+	fmt.Printf("\nACCESS TOKENS: \n\n")
+	tokensTable.Print()
+
+	return nil
+}
+
+func (cm *CommandManager) revokeAccessTokenCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	tokenID, err := strconv.ParseUint(paramsValidated["id"].value, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	return cm.authService.RevokeAccessToken(context.Background(), uint32(tokenID))
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func orAll(value string) string {
+	if value == "" {
+		return "all"
+	}
+	return value
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	clitable "github.com/bbquite/go-pass-keeper/pkg/table"
+)
+
+const (
+	auditDateLayout = "2006-01-02"
+	auditPageSize   = 20
+)
+
+func (cm *CommandManager) auditCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	filter := models.AuditFilter{
+		EventTypes: splitList(paramsValidated["types"].value),
+		Limit:      auditPageSize,
+	}
+
+	if value := strings.TrimSpace(paramsValidated["from"].value); value != "" {
+		from, err := time.ParseInLocation(auditDateLayout, value, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid from date %q", value)
+		}
+		filter.From = &from
+	}
+
+	if value := strings.TrimSpace(paramsValidated["to"].value); value != "" {
+		to, err := time.ParseInLocation(auditDateLayout, value, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid to date %q", value)
+		}
+		// the to date is inclusive
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		events, nextCursor, err := cm.authService.ListAuditEvents(context.Background(), filter)
+		if err != nil {
+			return err
+		}
+
+		auditTable := clitable.New([]string{"TIME", "EVENT", "RECORD", "SESSION", "TOKEN", "ADDRESS", "DETAILS"})
+		auditTable.Markdown = true
+
+		for _, item := range events {
+			auditTable.AddRow(map[string]interface{}{
+				"TIME":    item.CreatedAt.Format(displayTimeLayout),
+				"EVENT":   item.EventType,
+				"RECORD":  orEmpty(item.RecordID),
+				"SESSION": orEmpty(item.SessionID),
+				"TOKEN":   orEmpty(item.AccessTokenID),
+				"ADDRESS": item.PeerAddress,
+				"DETAILS": item.Details,
+			})
+		}
+
+		fmt.Printf("\nAUDIT LOG: \n\n")
+		auditTable.Print()
+
+		if nextCursor == 0 {
+			return nil
+		}
+
+		fmt.Print("Show more? (y/N): ")
+		scanner.Scan()
+		if !strings.EqualFold(strings.TrimSpace(scanner.Text()), "y") {
+			return nil
+		}
+		filter.BeforeID = nextCursor
+	}
+}
+
+func orEmpty(id uint32) string {
+	if id == 0 {
+		return ""
+	}
+	return fmt.Sprint(id)
+}
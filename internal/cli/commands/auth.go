@@ -3,9 +3,11 @@ package commands
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 
+	clientService "github.com/bbquite/go-pass-keeper/internal/service/client"
 	"github.com/bbquite/go-pass-keeper/internal/utils"
 	jwttoken "github.com/bbquite/go-pass-keeper/pkg/jwt_token"
 
@@ -14,12 +16,22 @@ import (
 
 func (cm *CommandManager) accountAction(params CommandParams, action func(ctx context.Context, userData *models.UserAccountData) error) error {
 	paramsValidated := cm.validateParams(params)
+	cm.dataService.LockVault()
 	loginData := &models.UserAccountData{
 		Username: paramsValidated["username"].value,
 		Password: paramsValidated["password"].value,
 	}
 
 	err := action(context.Background(), loginData)
+	if errors.Is(err, clientService.ErrMfaRequired) {
+		err = cm.mfaLoginCommand()
+	}
+	if err != nil {
+		return err
+	}
+
+	// The new session may belong to another account.
+	err = cm.localStorage.RemoveCache()
 	if err != nil {
 		return err
 	}
@@ -27,12 +39,88 @@ func (cm *CommandManager) accountAction(params CommandParams, action func(ctx co
 	return cm.saveTokenToFile()
 }
 
+func (cm *CommandManager) passwdCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	err := cm.authService.ChangePassword(
+		context.Background(),
+		paramsValidated["password"].value,
+		paramsValidated["new_password"].value,
+	)
+	if err != nil {
+		return err
+	}
+
+	return cm.saveTokenToFile()
+}
+
+func (cm *CommandManager) deleteAccountCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	err := cm.authService.DeleteAccount(
+		context.Background(),
+		paramsValidated["password"].value,
+		paramsValidated["code"].value,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = cm.localStorage.RemoveCache()
+	if err != nil {
+		return err
+	}
+
+	return cm.removeTokenFile()
+}
+
+func (cm *CommandManager) exportAccountCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	err := cm.dataService.ExportAccount(context.Background(), cm.accountExportFilePath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Account archive saved to %s\n", cm.accountExportFilePath)
+	return nil
+}
+
+func (cm *CommandManager) logoutCommand() error {
+	err := cm.authService.Logout(context.Background())
+	if err != nil {
+		return err
+	}
+	cm.dataService.LockVault()
+
+	err = cm.localStorage.RemoveCache()
+	if err != nil {
+		return err
+	}
+
+	return cm.removeTokenFile()
+}
+
+func (cm *CommandManager) removeTokenFile() error {
+	err := os.Remove(cm.authFilePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
 func (cm *CommandManager) checkTokenWrapper(dataType models.DataTypeEnum, params CommandParams, action CommandActionWithTypeParams) error {
-	token := cm.localStorage.GetToken()
-	if token == "" {
+	if !cm.authService.IsAuthorized() {
 		return fmt.Errorf("authorization only, run \"AUTH\"")
 	}
 
+	if dataType != models.DataTypeUNDEFINE {
+		// Offline, the vault state is unknown; the command works on cached
+		// records or fails on its own RPC.
+		err := cm.unlockVault()
+		if err != nil && !clientService.IsServerUnavailable(err) {
+			return err
+		}
+	}
+
 	return action(dataType, params)
 }
 
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+
+	clientService "github.com/bbquite/go-pass-keeper/internal/service/client"
+	"github.com/fatih/color"
+)
+
+const cacheTimeLayout = "2006-01-02 15:04:05"
+
+// openCache loads the encrypted local cache. Without a passphrase from the
+// environment it is asked for; an empty answer or a wrong passphrase leave
+// the cache disabled for this run.
+func (cm *CommandManager) openCache(path string, passphrase string) {
+	if passphrase == "" {
+		fmt.Print("Enter local cache passphrase (empty to disable the cache): ")
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Scan()
+		passphrase = scanner.Text()
+	}
+	if passphrase == "" {
+		return
+	}
+
+	err := cm.localStorage.OpenCache(path, passphrase)
+	if err != nil {
+		color.Red("local cache disabled: %v\n", err)
+		return
+	}
+
+	if cachedAt := cm.localStorage.CachedAt(); !cachedAt.IsZero() {
+		fmt.Printf("local cache loaded, synced at %s\n\n", cachedAt.Format(cacheTimeLayout))
+	}
+}
+
+// loadData syncs the records with the server. When the server is unreachable
+// the cached records are used, if there are any.
+func (cm *CommandManager) loadData() error {
+	err := cm.dataService.GetData(context.Background())
+	if err == nil || !clientService.IsServerUnavailable(err) {
+		return err
+	}
+
+	cachedAt := cm.localStorage.CachedAt()
+	if cachedAt.IsZero() {
+		return err
+	}
+
+	color.Yellow("server unreachable, showing records cached at %s\n", cachedAt.Format(cacheTimeLayout))
+	return nil
+}
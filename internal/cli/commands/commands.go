@@ -20,6 +20,7 @@ var (
 	ErrorNoExecution    = errors.New("no commands execution found")
 	ErrorUnknownCommand = errors.New("unknown commands")
 	ErrorGracefullyStop = errors.New("cli gracefully stop")
+	ErrorUnexpectedArgs = errors.New("command takes no arguments")
 )
 
 type (
@@ -36,9 +37,12 @@ type (
 	CommandThree   map[string]Command
 )
 
+// Command runs Execute, or ExecuteArgs when it takes arguments on the same
+// line, or asks for one of its Subcommands.
 type Command struct {
 	Desc        string
 	Execute     CommandExecute
+	ExecuteArgs func(args []string) error
 	Subcommands CommandThree
 }
 
@@ -74,33 +78,38 @@ func (c *Command) GetCommandHelp(lvl int, cmd Command) string {
 }
 
 type CommandManager struct {
-	localStorage       *local.ClientStorage
-	authService        *clientService.ClientAuthService
-	dataService        *clientService.ClientDataService
-	authFilePath       string
-	pairExportFilePath string
-	textExportFilePath string
-	cardExportFilePath string
-	helpInfo           string
-	CommandRoot        CommandThree
+	localStorage          *local.ClientStorage
+	authService           *clientService.ClientAuthService
+	dataService           *clientService.ClientDataService
+	authFilePath          string
+	pairExportFilePath    string
+	textExportFilePath    string
+	cardExportFilePath    string
+	accountExportFilePath string
+	currentFolder         uint32
+	helpInfo              string
+	CommandRoot           CommandThree
 }
 
-func NewCommandManager(grpcClient *client.GRPCClient, logger *zap.SugaredLogger) *CommandManager {
+func NewCommandManager(grpcClient *client.GRPCClient, deviceName string, cachePath string, cachePassphrase string, logger *zap.SugaredLogger) *CommandManager {
 
 	localStorage := local.NewClientStorage()
-	authService := clientService.NewClientAuthService(grpcClient, localStorage, logger)
-	dataService := clientService.NewClientDataService(grpcClient, localStorage, logger)
+	authService := clientService.NewClientAuthService(grpcClient, localStorage, deviceName, logger)
+	dataService := clientService.NewClientDataService(grpcClient, localStorage, authService, logger)
 
 	cm := &CommandManager{
-		localStorage:       localStorage,
-		authService:        authService,
-		dataService:        dataService,
-		authFilePath:       "./auth.json",
-		pairExportFilePath: "./pairExport.json",
-		textExportFilePath: "./textExport.json",
-		cardExportFilePath: "./cardExport.json",
+		localStorage:          localStorage,
+		authService:           authService,
+		dataService:           dataService,
+		authFilePath:          "./auth.json",
+		pairExportFilePath:    "./pairExport.json",
+		textExportFilePath:    "./textExport.json",
+		cardExportFilePath:    "./cardExport.json",
+		accountExportFilePath: "./accountExport.zip",
 	}
 
+	authService.OnTokenRefresh(cm.saveTokenToFile)
+
 	cm.initCommandsThree()
 	cm.generateHelpInfo()
 
@@ -109,6 +118,10 @@ func NewCommandManager(grpcClient *client.GRPCClient, logger *zap.SugaredLogger)
 		fmt.Printf("auth file not found: %v\n\n", err)
 	}
 
+	if cachePath != "" {
+		cm.openCache(cachePath, cachePassphrase)
+	}
+
 	fmt.Print(cm.helpInfo)
 
 	return cm
@@ -168,12 +181,58 @@ func (cm *CommandManager) initCommandsThree() {
 				return cm.accountAction(authParams, cm.authService.RegisterUser)
 			},
 		},
-		"SHOW": {
-			Desc: "Show records from remote server",
+		"LOGOUT": {
+			Desc: "Log out: revoke the token on the server and delete the local auth file",
+			Execute: func() error {
+				return cm.logoutCommand()
+			},
+		},
+		"PASSWD": {
+			Desc: "Change the account password and log out all other sessions",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, passwdParams, cm.passwdCommand)
+			},
+		},
+		"ACCOUNT": {
+			Desc:        "Export or delete the account",
+			Subcommands: cm.initAccountCommands(),
+		},
+		"MFA": {
+			Desc:        "Two-factor authentication settings",
+			Subcommands: cm.initMfaCommands(),
+		},
+		"TOKENS": {
+			Desc:        "Personal access tokens for scripts",
+			Subcommands: cm.initAccessTokenCommands(),
+		},
+		"SESSIONS": {
+			Desc:        "List or revoke logged in devices",
+			Subcommands: cm.initSessionCommands(),
+		},
+		"AUDIT": {
+			Desc: "Show the account audit log",
 			Execute: func() error {
-				return cm.showCommand()
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, auditParams, cm.auditCommand)
 			},
 		},
+		"VAULT": {
+			Desc:        "End-to-end encryption with a master password",
+			Subcommands: cm.initVaultCommands(),
+		},
+		"SHOW": {
+			Desc: "Show records of the current folder, all records at the top level; SHOW --tag work filters by tag",
+			ExecuteArgs: func(args []string) error {
+				return cm.showCommand(args)
+			},
+		},
+		"TAGS": {
+			Desc:        "Tag records and list tags",
+			Subcommands: cm.initTagsCommands(),
+		},
+		"FOLDER": {
+			Desc:        "Organize records in folders",
+			Subcommands: cm.initFolderCommands(),
+		},
 		"GET": {
 			Desc:        "Download data from remote server",
 			Subcommands: cm.initExportCommands(),
@@ -186,8 +245,16 @@ func (cm *CommandManager) initCommandsThree() {
 			Desc:        "Updating a record in the system",
 			Subcommands: cm.initUpdateCommands(),
 		},
+		"HISTORY": {
+			Desc:        "Previous versions of a record",
+			Subcommands: cm.initHistoryCommands(),
+		},
+		"TRASH": {
+			Desc:        "Restore or permanently delete deleted records",
+			Subcommands: cm.initTrashCommands(),
+		},
 		"DEL": {
-			Desc: "Deleting a record in the system",
+			Desc: "Move a record to the trash",
 			Execute: func() error {
 				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, wrapIDParam(emptyParams), cm.deleteCommand)
 			},
@@ -215,6 +282,247 @@ func (cm *CommandManager) initCommandsThree() {
 	cm.CommandRoot = commandRoot
 }
 
+func (cm *CommandManager) initAccountCommands() CommandThree {
+	var p CommandParams
+	cmThree := CommandThree{
+		"EXPORT": {
+			Desc: "Download all records and account history as a zip archive",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, p, cm.exportAccountCommand)
+			},
+		},
+		"DELETE": {
+			Desc: "Permanently delete the account and all records",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, deleteAccountParams, cm.deleteAccountCommand)
+			},
+		},
+	}
+
+	return cmThree
+}
+
+func (cm *CommandManager) initMfaCommands() CommandThree {
+	var p CommandParams
+	cmThree := CommandThree{
+		"ENROLL": {
+			Desc: "Generate a TOTP secret and QR code",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, p, cm.mfaEnrollCommand)
+			},
+		},
+		"CONFIRM": {
+			Desc: "Enable two-factor authentication with a code from the app",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, mfaCodeParams, cm.mfaConfirmCommand)
+			},
+		},
+		"DISABLE": {
+			Desc: "Disable two-factor authentication",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, mfaDisableParams, cm.mfaDisableCommand)
+			},
+		},
+	}
+
+	return cmThree
+}
+
+func (cm *CommandManager) initSessionCommands() CommandThree {
+	var p CommandParams
+	cmThree := CommandThree{
+		"LIST": {
+			Desc: "Show active sessions",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, p, cm.listSessionsCommand)
+			},
+		},
+		"REVOKE": {
+			Desc: "Log out a session (by ID)",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, wrapIDParam(CommandParams{}), cm.revokeSessionCommand)
+			},
+		},
+	}
+
+	return cmThree
+}
+
+func (cm *CommandManager) initHistoryCommands() CommandThree {
+	cmThree := CommandThree{
+		"LIST": {
+			Desc: "Show the versions of a record (by ID)",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, wrapIDParam(CommandParams{}), cm.historyListCommand)
+			},
+		},
+		"SHOW": {
+			Desc: "Show the content of a version",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, historyVersionParams, cm.historyShowCommand)
+			},
+		},
+		"RESTORE": {
+			Desc: "Make a version the current content of the record",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, historyVersionParams, cm.historyRestoreCommand)
+			},
+		},
+	}
+
+	return cmThree
+}
+
+func (cm *CommandManager) initFolderCommands() CommandThree {
+	cmThree := CommandThree{
+		"TREE": {
+			Desc: "Show folders and records of the current folder as a tree",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, emptyParams, cm.folderTreeCommand)
+			},
+		},
+		"CD": {
+			Desc: "Change the current folder used by SHOW, TREE and CREATE",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, folderCdParams, cm.folderCdCommand)
+			},
+		},
+		"CREATE": {
+			Desc: "Create a folder in the current folder",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, folderCreateParams, cm.folderCreateCommand)
+			},
+		},
+		"RENAME": {
+			Desc: "Rename a folder (by ID)",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, folderRenameParams, cm.folderRenameCommand)
+			},
+		},
+		"MOVE": {
+			Desc: "Move a folder into another folder (by ID)",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, folderMoveParams, cm.folderMoveCommand)
+			},
+		},
+		"DELETE": {
+			Desc: "Delete a folder (by ID), keeping its records",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, wrapIDParam(CommandParams{}), cm.folderDeleteCommand)
+			},
+		},
+		"PUT": {
+			Desc: "Move a record into a folder (by ID)",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, folderPutParams, cm.folderPutCommand)
+			},
+		},
+	}
+
+	return cmThree
+}
+
+func (cm *CommandManager) initTagsCommands() CommandThree {
+	cmThree := CommandThree{
+		"ADD": {
+			Desc: "Add tags to a record (by ID)",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, tagsParams, cm.tagsAddCommand)
+			},
+		},
+		"REMOVE": {
+			Desc: "Remove tags from a record (by ID)",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, tagsParams, cm.tagsRemoveCommand)
+			},
+		},
+		"LIST": {
+			Desc: "Show all tags with the number of records",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, emptyParams, cm.tagsListCommand)
+			},
+		},
+	}
+
+	return cmThree
+}
+
+func (cm *CommandManager) initTrashCommands() CommandThree {
+	cmThree := CommandThree{
+		"LIST": {
+			Desc: "Show deleted records",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, emptyParams, cm.trashListCommand)
+			},
+		},
+		"RESTORE": {
+			Desc: "Restore a deleted record (by ID)",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, wrapIDParam(CommandParams{}), cm.trashRestoreCommand)
+			},
+		},
+		"PURGE": {
+			Desc: "Permanently delete a record in the trash (by ID)",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, wrapIDParam(CommandParams{}), cm.trashPurgeCommand)
+			},
+		},
+	}
+
+	return cmThree
+}
+
+func (cm *CommandManager) initVaultCommands() CommandThree {
+	cmThree := CommandThree{
+		"ENABLE": {
+			Desc: "Set a master password and encrypt all records on this device",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, vaultEnableParams, cm.vaultEnableCommand)
+			},
+		},
+		"UNLOCK": {
+			Desc: "Enter the master password",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, masterPasswordParams, cm.vaultUnlockCommand)
+			},
+		},
+		"SEAL": {
+			Desc: "Encrypt records left unencrypted by an interrupted ENABLE",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, emptyParams, cm.vaultSealCommand)
+			},
+		},
+	}
+
+	return cmThree
+}
+
+func (cm *CommandManager) initAccessTokenCommands() CommandThree {
+	var p CommandParams
+	cmThree := CommandThree{
+		"CREATE": {
+			Desc: "Create a scoped access token",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, accessTokenParams, cm.createAccessTokenCommand)
+			},
+		},
+		"LIST": {
+			Desc: "Show active access tokens",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, p, cm.listAccessTokensCommand)
+			},
+		},
+		"REVOKE": {
+			Desc: "Revoke an access token (by ID)",
+			Execute: func() error {
+				return cm.checkTokenWrapper(models.DataTypeUNDEFINE, wrapIDParam(CommandParams{}), cm.revokeAccessTokenCommand)
+			},
+		},
+	}
+
+	return cmThree
+}
+
 func (cm *CommandManager) initExportCommands() CommandThree {
 	var p CommandParams
 	cmThree := CommandThree{
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	"github.com/bbquite/go-pass-keeper/internal/models"
@@ -15,10 +16,13 @@ func (cm *CommandManager) createCommand(dataType models.DataTypeEnum, params Com
 		return err
 	}
 
+	folders, _ := cm.localStorage.GetFolders()
+
 	err = cm.dataService.CreateData(context.Background(), &models.DataStoreFormat{
 		DataType: dataType,
 		DataInfo: string(dataMarshal),
 		Meta:     paramsValidated["meta"].value,
+		FolderID: cm.validCurrentFolder(folders),
 	})
 	if err != nil {
 		return err
@@ -59,9 +63,15 @@ func (cm *CommandManager) deleteCommand(dataType models.DataTypeEnum, params Com
 		return err
 	}
 
+	if !confirm(fmt.Sprintf("Move record %d to the trash?", dataID)) {
+		return nil
+	}
+
 	err = cm.dataService.DeleteData(context.Background(), uint32(dataID))
 	if err != nil {
 		return err
 	}
+
+	fmt.Printf("Record %d moved to the trash, run \"TRASH RESTORE\" to undo\n", dataID)
 	return nil
 }
@@ -3,14 +3,17 @@ package commands
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
+
 	"github.com/bbquite/go-pass-keeper/internal/models"
+	clientService "github.com/bbquite/go-pass-keeper/internal/service/client"
 	"github.com/bbquite/go-pass-keeper/internal/utils"
-	"strconv"
 )
 
 func (cm *CommandManager) exportCommand(dataType models.DataTypeEnum, params CommandParams) error {
 
-	err := cm.dataService.GetData(context.Background())
+	err := cm.loadData()
 	if err != nil {
 		return err
 	}
@@ -79,16 +82,23 @@ func (cm *CommandManager) exportCardData() error {
 }
 
 func (cm *CommandManager) exportBinaryData(dataID uint32) error {
-	dataItem, err := cm.dataService.GetDataByID(context.Background(), dataID)
-	if err != nil {
-		return err
-	}
-
 	m := models.BinaryData{}
-	d := []byte(dataItem.DataInfo)
-	err = json.Unmarshal(d, &m)
-	if err != nil {
+
+	dataItem, err := cm.dataService.GetDataByID(context.Background(), dataID)
+	switch {
+	case clientService.IsServerUnavailable(err):
+		m, err = cm.cachedBinary(dataID)
+		if err != nil {
+			return err
+		}
+	case err != nil:
 		return err
+	default:
+		d := []byte(dataItem.DataInfo)
+		err = json.Unmarshal(d, &m)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = utils.SaveFile("./"+m.FileName, m.Binary)
@@ -98,3 +108,14 @@ func (cm *CommandManager) exportBinaryData(dataID uint32) error {
 
 	return nil
 }
+
+// cachedBinary finds a file record in the local cache.
+func (cm *CommandManager) cachedBinary(dataID uint32) (models.BinaryData, error) {
+	binaries, _ := cm.localStorage.GetBinary()
+	for _, item := range binaries {
+		if item.ID == dataID {
+			return item, nil
+		}
+	}
+	return models.BinaryData{}, fmt.Errorf("server unreachable and file %d is not cached", dataID)
+}
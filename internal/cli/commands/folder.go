@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	clientService "github.com/bbquite/go-pass-keeper/internal/service/client"
+)
+
+// folderRecord is a stored record of any type as shown in the folder tree.
+type folderRecord struct {
+	id       uint32
+	dataType models.DataTypeEnum
+	meta     string
+	folderID uint32
+	tags     []string
+}
+
+func (cm *CommandManager) folderTreeCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	err := cm.unlockVault()
+	if err != nil && !clientService.IsServerUnavailable(err) {
+		return err
+	}
+
+	err = cm.loadData()
+	if err != nil {
+		return err
+	}
+
+	folders, _ := cm.localStorage.GetFolders()
+	root := cm.validCurrentFolder(folders)
+
+	fmt.Printf("\n%s\n", folderPath(folders, root))
+	printFolderTree(folders, cm.storedRecords(), root, "")
+
+	return nil
+}
+
+func (cm *CommandManager) folderCdCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	folderID, err := strconv.ParseUint(paramsValidated["folder"].value, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	err = cm.unlockVault()
+	if err != nil && !clientService.IsServerUnavailable(err) {
+		return err
+	}
+
+	err = cm.loadData()
+	if err != nil {
+		return err
+	}
+
+	folders, _ := cm.localStorage.GetFolders()
+	if folderID != 0 && findFolder(folders, uint32(folderID)) == nil {
+		return fmt.Errorf("folder %d not found", folderID)
+	}
+
+	cm.currentFolder = uint32(folderID)
+	fmt.Printf("Current folder: %s\n", folderPath(folders, cm.currentFolder))
+
+	return nil
+}
+
+func (cm *CommandManager) folderCreateCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	err := cm.unlockVault()
+	if err != nil {
+		return err
+	}
+
+	folders, _ := cm.localStorage.GetFolders()
+	return cm.dataService.CreateFolder(context.Background(), paramsValidated["name"].value, cm.validCurrentFolder(folders))
+}
+
+func (cm *CommandManager) folderRenameCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	folderID, err := strconv.ParseUint(paramsValidated["id"].value, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	err = cm.unlockVault()
+	if err != nil {
+		return err
+	}
+
+	return cm.dataService.RenameFolder(context.Background(), uint32(folderID), paramsValidated["name"].value)
+}
+
+func (cm *CommandManager) folderMoveCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	folderID, err := strconv.ParseUint(paramsValidated["id"].value, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	parentID, err := strconv.ParseUint(paramsValidated["parent"].value, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	return cm.dataService.MoveFolder(context.Background(), uint32(folderID), uint32(parentID))
+}
+
+func (cm *CommandManager) folderDeleteCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	folderID, err := strconv.ParseUint(paramsValidated["id"].value, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	if !confirm(fmt.Sprintf("Delete folder %d? Its records and subfolders move to the parent folder", folderID)) {
+		return nil
+	}
+
+	return cm.dataService.DeleteFolder(context.Background(), uint32(folderID))
+}
+
+func (cm *CommandManager) folderPutCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	dataID, err := strconv.ParseUint(paramsValidated["id"].value, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	folderID, err := strconv.ParseUint(paramsValidated["folder"].value, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	return cm.dataService.MoveData(context.Background(), uint32(dataID), uint32(folderID))
+}
+
+// validCurrentFolder returns the current folder, falling back to the top
+// level when it no longer exists, e.g. after it was deleted or on logout.
+func (cm *CommandManager) validCurrentFolder(folders []models.Folder) uint32 {
+	if cm.currentFolder != 0 && findFolder(folders, cm.currentFolder) == nil {
+		cm.currentFolder = 0
+	}
+	return cm.currentFolder
+}
+
+// inCurrentFolder reports whether SHOW prints a record of the folder. The
+// top level shows every record.
+func (cm *CommandManager) inCurrentFolder(folderID uint32) bool {
+	return cm.currentFolder == 0 || cm.currentFolder == folderID
+}
+
+func (cm *CommandManager) storedRecords() []folderRecord {
+	var result []folderRecord
+
+	pairs, _ := cm.localStorage.GetPairs()
+	for _, item := range pairs {
+		result = append(result, folderRecord{id: item.ID, dataType: models.DataTypePAIR, meta: item.Meta, folderID: item.FolderID, tags: item.Tags})
+	}
+	texts, _ := cm.localStorage.GetTexts()
+	for _, item := range texts {
+		result = append(result, folderRecord{id: item.ID, dataType: models.DataTypeTEXT, meta: item.Meta, folderID: item.FolderID, tags: item.Tags})
+	}
+	bin, _ := cm.localStorage.GetBinary()
+	for _, item := range bin {
+		result = append(result, folderRecord{id: item.ID, dataType: models.DataTypeBINARY, meta: item.Meta, folderID: item.FolderID, tags: item.Tags})
+	}
+	cards, _ := cm.localStorage.GetCards()
+	for _, item := range cards {
+		result = append(result, folderRecord{id: item.ID, dataType: models.DataTypeCARD, meta: item.Meta, folderID: item.FolderID, tags: item.Tags})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].id < result[j].id })
+	return result
+}
+
+// printFolderTree prints the subfolders of parentID, then its records, and
+// descends into every subfolder.
+func printFolderTree(folders []models.Folder, records []folderRecord, parentID uint32, prefix string) {
+	var children []models.Folder
+	for _, folder := range folders {
+		if folder.ParentID == parentID && folder.ID != parentID {
+			children = append(children, folder)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+
+	var items []folderRecord
+	for _, record := range records {
+		if record.folderID == parentID {
+			items = append(items, record)
+		}
+	}
+
+	total := len(children) + len(items)
+	for i, folder := range children {
+		branch, indent := treeBranch(i == total-1)
+		fmt.Printf("%s%s%s/ (folder %d)\n", prefix, branch, folder.Name, folder.ID)
+		printFolderTree(folders, records, folder.ID, prefix+indent)
+	}
+	for i, record := range items {
+		branch, _ := treeBranch(len(children)+i == total-1)
+		fmt.Printf("%s%s[%d] %s %s", prefix, branch, record.id, record.dataType, record.meta)
+		for _, tag := range record.tags {
+			fmt.Printf(" #%s", tag)
+		}
+		fmt.Println()
+	}
+}
+
+func treeBranch(last bool) (string, string) {
+	if last {
+		return "└── ", "    "
+	}
+	return "├── ", "│   "
+}
+
+func findFolder(folders []models.Folder, folderID uint32) *models.Folder {
+	for i := range folders {
+		if folders[i].ID == folderID {
+			return &folders[i]
+		}
+	}
+	return nil
+}
+
+// folderPath returns the path of the folder from the top level, like
+// "/work/servers".
+func folderPath(folders []models.Folder, folderID uint32) string {
+	var names []string
+	// The depth limit guards against a parent loop in stale cached data.
+	for depth := 0; folderID != 0 && depth <= len(folders); depth++ {
+		folder := findFolder(folders, folderID)
+		if folder == nil {
+			break
+		}
+		names = append([]string{folder.Name}, names...)
+		folderID = folder.ParentID
+	}
+	return "/" + strings.Join(names, "/")
+}
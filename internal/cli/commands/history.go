@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	clitable "github.com/bbquite/go-pass-keeper/pkg/table"
+)
+
+func (cm *CommandManager) historyListCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	dataID, err := strconv.ParseUint(paramsValidated["id"].value, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	versions, err := cm.dataService.ListVersions(context.Background(), uint32(dataID))
+	if err != nil {
+		return err
+	}
+
+	if len(versions) == 0 {
+		fmt.Printf("\nRecord %d has no previous versions\n", dataID)
+		return nil
+	}
+
+	versionsTable := clitable.New([]string{"VERSION", "WRITTEN", "REPLACED"})
+	versionsTable.Markdown = true
+
+	for _, item := range versions {
+		versionsTable.AddRow(map[string]interface{}{
+			"VERSION":  item.Version,
+			"WRITTEN":  item.Data.UploadedAt.Format(displayTimeLayout),
+			"REPLACED": item.ArchivedAt.Format(displayTimeLayout),
+		})
+	}
+
+	fmt.Printf("\nVERSIONS OF RECORD %d: \n\n", dataID)
+	versionsTable.Print()
+
+	return nil
+}
+
+func (cm *CommandManager) historyShowCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	dataID, version, err := cm.parseVersionParams(params)
+	if err != nil {
+		return err
+	}
+
+	err = cm.unlockVault()
+	if err != nil {
+		return err
+	}
+
+	item, err := cm.dataService.GetVersion(context.Background(), dataID, version)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nRecord %d, version %d (%s)\n", dataID, item.Version, item.Data.DataType)
+	fmt.Printf("Written: %s\n", item.Data.UploadedAt.Format(displayTimeLayout))
+	fmt.Printf("Replaced: %s\n", item.ArchivedAt.Format(displayTimeLayout))
+	fmt.Printf("Meta: %s\n", item.Data.Meta)
+
+	if item.Data.DataType == models.DataTypeBINARY {
+		m := models.BinaryData{}
+		err = json.Unmarshal([]byte(item.Data.DataInfo), &m)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("File: %s (%d bytes)\n", m.FileName, m.FileSize)
+		return nil
+	}
+
+	var out bytes.Buffer
+	err = json.Indent(&out, []byte(item.Data.DataInfo), "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Data: %s\n", out.String())
+
+	return nil
+}
+
+func (cm *CommandManager) historyRestoreCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	dataID, version, err := cm.parseVersionParams(params)
+	if err != nil {
+		return err
+	}
+
+	err = cm.dataService.RestoreVersion(context.Background(), dataID, version)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Record %d restored to version %d, the replaced content is kept as the newest version\n", dataID, version)
+	return nil
+}
+
+func (cm *CommandManager) parseVersionParams(params CommandParams) (uint32, uint32, error) {
+	paramsValidated := cm.validateParams(params)
+
+	dataID, err := strconv.ParseUint(paramsValidated["id"].value, 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	version, err := strconv.ParseUint(paramsValidated["version"].value, 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return uint32(dataID), uint32(version), nil
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+)
+
+func (cm *CommandManager) mfaLoginCommand() error {
+	paramsValidated := cm.validateParams(mfaCodeParams)
+	return cm.authService.CompleteMfaLogin(context.Background(), paramsValidated["code"].value)
+}
+
+func (cm *CommandManager) mfaEnrollCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	enrollment, err := cm.authService.EnrollTotp(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nScan the QR code with an authenticator app:\n\n%s\n", enrollment.QRCode)
+	fmt.Printf("Or enter the secret manually: %s\n", enrollment.Secret)
+	fmt.Printf("URI: %s\n\n", enrollment.URI)
+	fmt.Print("Then run \"MFA\" -> \"CONFIRM\" with a code from the app\n")
+
+	return nil
+}
+
+func (cm *CommandManager) mfaConfirmCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	recoveryCodes, err := cm.authService.ConfirmTotp(context.Background(), paramsValidated["code"].value)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print("\nTwo-factor authentication enabled. Recovery codes (each works once, store them safely):\n\n")
+	for _, code := range recoveryCodes {
+		fmt.Printf("    %s\n", code)
+	}
+
+	return nil
+}
+
+func (cm *CommandManager) mfaDisableCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	return cm.authService.DisableTotp(
+		context.Background(),
+		paramsValidated["password"].value,
+		paramsValidated["code"].value,
+	)
+}
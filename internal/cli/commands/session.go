@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	clitable "github.com/bbquite/go-pass-keeper/pkg/table"
+)
+
+const displayTimeLayout = "2006-01-02 15:04"
+
+func (cm *CommandManager) listSessionsCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	sessions, err := cm.authService.ListSessions(context.Background())
+	if err != nil {
+		return err
+	}
+
+	sessionsTable := clitable.New([]string{"ID", "DEVICE", "ADDRESS", "CLIENT", "CREATED", "LAST SEEN", "CURRENT"})
+	sessionsTable.Markdown = true
+
+	for _, item := range sessions {
+		current := ""
+		if item.Current {
+			current = "*"
+		}
+
+		sessionsTable.AddRow(map[string]interface{}{
+			"ID":        item.ID,
+			"DEVICE":    item.DeviceName,
+			"ADDRESS":   item.PeerAddress,
+			"CLIENT":    item.UserAgent,
+			"CREATED":   item.CreatedAt.Format(displayTimeLayout),
+			"LAST SEEN": item.LastSeenAt.Format(displayTimeLayout),
+			"CURRENT":   current,
+		})
+	}
+
+	fmt.Printf("\nSESSIONS: \n\n")
+	sessionsTable.Print()
+
+	return nil
+}
+
+func (cm *CommandManager) revokeSessionCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	sessionID, err := strconv.ParseUint(paramsValidated["id"].value, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	return cm.authService.RevokeSession(context.Background(), uint32(sessionID))
+}
@@ -1,45 +1,119 @@
 package commands
 
 import (
-	"context"
+	"flag"
 	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	clientService "github.com/bbquite/go-pass-keeper/internal/service/client"
 	clitable "github.com/bbquite/go-pass-keeper/pkg/table"
 )
 
-func (cm *CommandManager) showCommand() error {
+// recordFilter reports whether SHOW prints a record of the folder with the
+// tags.
+type recordFilter func(folderID uint32, tags []string) bool
+
+// tagList collects a repeated -tag flag.
+type tagList []string
+
+func (t *tagList) String() string {
+	return strings.Join(*t, ",")
+}
+
+func (t *tagList) Set(value string) error {
+	*t = append(*t, strings.TrimSpace(value))
+	return nil
+}
+
+func (cm *CommandManager) showCommand(args []string) error {
+	var tags tagList
+	flags := flag.NewFlagSet("SHOW", flag.ContinueOnError)
+	flags.Var(&tags, "tag", "show records having the tag, repeat to require several")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+
+	// Tags are matched here, after the vault opened them, as the server
+	// cannot compare sealed tags.
+	visible := func(folderID uint32, recordTags []string) bool {
+		if !cm.inCurrentFolder(folderID) {
+			return false
+		}
+		for _, tag := range tags {
+			if !slices.Contains(recordTags, tag) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if cm.authService.IsAuthorized() {
+		err := cm.unlockVault()
+		if err != nil && !clientService.IsServerUnavailable(err) {
+			return err
+		}
+	}
 
-	err := cm.dataService.GetData(context.Background())
+	err = cm.loadData()
 	if err != nil {
 		return err
 	}
 
-	cm.printPairs()
-	cm.printCards()
-	cm.printBinary()
-	cm.printTexts()
+	folders, _ := cm.localStorage.GetFolders()
+	if cm.validCurrentFolder(folders) != 0 {
+		fmt.Printf("\nFOLDER: %s\n", folderPath(folders, cm.currentFolder))
+		for _, folder := range folders {
+			if folder.ParentID == cm.currentFolder {
+				fmt.Printf("  %s/ (folder %d)\n", folder.Name, folder.ID)
+			}
+		}
+	}
+
+	cm.printPairs(visible)
+	cm.printCards(visible)
+	cm.printBinary(visible)
+	cm.printTexts(visible)
 
 	return nil
 }
 
-func (cm *CommandManager) printPairs() {
-	pairs, _ := cm.localStorage.GetPairs()
+func (cm *CommandManager) printPairs(visible recordFilter) {
+	var pairs []models.PairData
+	stored, _ := cm.localStorage.GetPairs()
+	for _, item := range stored {
+		if visible(item.FolderID, item.Tags) {
+			pairs = append(pairs, item)
+		}
+	}
 	if len(pairs) == 0 {
 		return
 	}
 
-	pairsTable := clitable.New([]string{"ID", "KEY", "PWD", "META"})
+	pairsTable := clitable.New([]string{"ID", "KEY", "PWD", "META", "TAGS"})
 	pairsTable.Markdown = true
 
 	for _, item := range pairs {
-		pairsTable.AddRow(map[string]interface{}{"ID": item.ID, "KEY": item.Key, "PWD": item.Pwd, "META": item.Meta})
+		pairsTable.AddRow(map[string]interface{}{"ID": item.ID, "KEY": item.Key, "PWD": item.Pwd, "META": item.Meta, "TAGS": strings.Join(item.Tags, ", ")})
 	}
 
 	fmt.Printf("\nPAIR DATA: \n\n")
 	pairsTable.Print()
 }
 
-func (cm *CommandManager) printTexts() {
-	texts, _ := cm.localStorage.GetTexts()
+func (cm *CommandManager) printTexts(visible recordFilter) {
+	var texts []models.TextData
+	stored, _ := cm.localStorage.GetTexts()
+	for _, item := range stored {
+		if visible(item.FolderID, item.Tags) {
+			texts = append(texts, item)
+		}
+	}
 	if len(texts) == 0 {
 		return
 	}
@@ -48,42 +122,58 @@ func (cm *CommandManager) printTexts() {
 	for _, item := range texts {
 		fmt.Printf("ID: %d\n", item.ID)
 		fmt.Printf("Meta: %s\n", item.Meta)
+		if len(item.Tags) > 0 {
+			fmt.Printf("Tags: %s\n", strings.Join(item.Tags, ", "))
+		}
 		fmt.Printf("Text: %s\n", item.Text)
 		fmt.Println("--------------------")
 	}
 }
 
-func (cm *CommandManager) printCards() {
-	cards, _ := cm.localStorage.GetCards()
+func (cm *CommandManager) printCards(visible recordFilter) {
+	var cards []models.CardData
+	stored, _ := cm.localStorage.GetCards()
+	for _, item := range stored {
+		if visible(item.FolderID, item.Tags) {
+			cards = append(cards, item)
+		}
+	}
 	if len(cards) == 0 {
 		return
 	}
 
-	cardsTable := clitable.New([]string{"ID", "NUM", "CVV", "EXP", "OWNER", "META"})
+	cardsTable := clitable.New([]string{"ID", "NUM", "CVV", "EXP", "OWNER", "META", "TAGS"})
 	cardsTable.Markdown = true
 
 	for _, item := range cards {
 		cardsTable.AddRow(map[string]interface{}{
 			"ID": item.ID, "NUM": item.CardNum,
 			"CVV": item.CardCvv, "EXP": item.CardExp,
-			"OWNER": item.CardOwner, "META": item.Meta})
+			"OWNER": item.CardOwner, "META": item.Meta,
+			"TAGS": strings.Join(item.Tags, ", ")})
 	}
 
 	fmt.Printf("\nCARD DATA: \n\n")
 	cardsTable.Print()
 }
 
-func (cm *CommandManager) printBinary() {
-	bin, _ := cm.localStorage.GetBinary()
+func (cm *CommandManager) printBinary(visible recordFilter) {
+	var bin []models.BinaryData
+	stored, _ := cm.localStorage.GetBinary()
+	for _, item := range stored {
+		if visible(item.FolderID, item.Tags) {
+			bin = append(bin, item)
+		}
+	}
 	if len(bin) == 0 {
 		return
 	}
 
-	binTable := clitable.New([]string{"ID", "NAME", "SIZE", "META"})
+	binTable := clitable.New([]string{"ID", "NAME", "SIZE", "META", "TAGS"})
 	binTable.Markdown = true
 
 	for _, item := range bin {
-		binTable.AddRow(map[string]interface{}{"ID": item.ID, "NAME": item.FileName, "SIZE": item.FileSize, "META": item.Meta})
+		binTable.AddRow(map[string]interface{}{"ID": item.ID, "NAME": item.FileName, "SIZE": item.FileSize, "META": item.Meta, "TAGS": strings.Join(item.Tags, ", ")})
 	}
 
 	fmt.Printf("\nBINARY DATA: \n\n")
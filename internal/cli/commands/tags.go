@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	clientService "github.com/bbquite/go-pass-keeper/internal/service/client"
+	clitable "github.com/bbquite/go-pass-keeper/pkg/table"
+)
+
+func (cm *CommandManager) tagsAddCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	dataID, tags, err := cm.parseTagsParams(params)
+	if err != nil {
+		return err
+	}
+
+	return cm.dataService.AddTags(context.Background(), dataID, tags)
+}
+
+func (cm *CommandManager) tagsRemoveCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	dataID, tags, err := cm.parseTagsParams(params)
+	if err != nil {
+		return err
+	}
+
+	return cm.dataService.RemoveTags(context.Background(), dataID, tags)
+}
+
+func (cm *CommandManager) tagsListCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	err := cm.unlockVault()
+	if err != nil && !clientService.IsServerUnavailable(err) {
+		return err
+	}
+
+	err = cm.loadData()
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]int)
+	for _, record := range cm.storedRecords() {
+		for _, tag := range record.tags {
+			counts[tag]++
+		}
+	}
+
+	if len(counts) == 0 {
+		fmt.Printf("\nNo tags, run \"TAGS ADD\"\n")
+		return nil
+	}
+
+	tags := make([]string, 0, len(counts))
+	for tag := range counts {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if counts[tags[i]] != counts[tags[j]] {
+			return counts[tags[i]] > counts[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+
+	tagsTable := clitable.New([]string{"TAG", "RECORDS"})
+	tagsTable.Markdown = true
+
+	for _, tag := range tags {
+		tagsTable.AddRow(map[string]interface{}{"TAG": tag, "RECORDS": counts[tag]})
+	}
+
+	fmt.Printf("\nTAGS: \n\n")
+	tagsTable.Print()
+
+	return nil
+}
+
+func (cm *CommandManager) parseTagsParams(params CommandParams) (uint32, []string, error) {
+	paramsValidated := cm.validateParams(params)
+
+	dataID, err := strconv.ParseUint(paramsValidated["id"].value, 10, 32)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	tags := splitList(paramsValidated["tags"].value)
+	if len(tags) == 0 {
+		return 0, nil, fmt.Errorf("no tags given")
+	}
+
+	// Sealed tags are compared on this device.
+	err = cm.unlockVault()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return uint32(dataID), tags, nil
+}
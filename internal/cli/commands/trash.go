@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	clitable "github.com/bbquite/go-pass-keeper/pkg/table"
+)
+
+func (cm *CommandManager) trashListCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	err := cm.unlockVault()
+	if err != nil {
+		return err
+	}
+
+	items, err := cm.dataService.ListTrash(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if len(items) == 0 {
+		fmt.Printf("\nTrash is empty\n")
+		return nil
+	}
+
+	trashTable := clitable.New([]string{"ID", "TYPE", "META", "DELETED"})
+	trashTable.Markdown = true
+
+	for _, item := range items {
+		trashTable.AddRow(map[string]interface{}{
+			"ID":      item.Data.ID,
+			"TYPE":    item.Data.DataType,
+			"META":    item.Data.Meta,
+			"DELETED": item.DeletedAt.Format(displayTimeLayout),
+		})
+	}
+
+	fmt.Printf("\nTRASH: \n\n")
+	trashTable.Print()
+
+	return nil
+}
+
+func (cm *CommandManager) trashRestoreCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	dataID, err := strconv.ParseUint(paramsValidated["id"].value, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	return cm.dataService.RestoreData(context.Background(), uint32(dataID))
+}
+
+func (cm *CommandManager) trashPurgeCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	dataID, err := strconv.ParseUint(paramsValidated["id"].value, 10, 32)
+	if err != nil {
+		return err
+	}
+
+	if !confirm(fmt.Sprintf("Permanently delete record %d and its history? This cannot be undone", dataID)) {
+		return nil
+	}
+
+	return cm.dataService.PurgeData(context.Background(), uint32(dataID))
+}
+
+// confirm asks a yes/no question, defaulting to no.
+func confirm(question string) bool {
+	fmt.Printf("%s (y/N): ", question)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Scan()
+	return strings.EqualFold(strings.TrimSpace(scanner.Text()), "y")
+}
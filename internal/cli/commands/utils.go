@@ -16,6 +16,111 @@ var (
 		"password": {validateFunc: validator.StringValidation},
 	}
 
+	passwdParams = CommandParams{
+		"password":     {validateFunc: validator.StringValidation, usage: "current"},
+		"new_password": {validateFunc: validator.StringValidation},
+	}
+
+	deleteAccountParams = CommandParams{
+		"password": {validateFunc: validator.StringValidation},
+		"code": {
+			validateFunc: validator.StringValidationOptional,
+			usage:        "only if two-factor authentication is enabled",
+		},
+	}
+
+	mfaCodeParams = CommandParams{
+		"code": {
+			validateFunc: validator.StringValidation,
+			usage:        "6 digits or recovery code",
+		},
+	}
+
+	mfaDisableParams = CommandParams{
+		"password": {validateFunc: validator.StringValidation},
+		"code": {
+			validateFunc: validator.StringValidation,
+			usage:        "6 digits or recovery code",
+		},
+	}
+
+	accessTokenParams = CommandParams{
+		"name": {validateFunc: validator.StringValidation},
+		"days": {validateFunc: validator.IntValidation, usage: "lifetime, up to 365"},
+		"read_only": {
+			validateFunc: validator.StringValidationOptional,
+			usage:        "y/N",
+		},
+		"types": {
+			validateFunc: validator.StringValidationOptional,
+			usage:        "comma separated PAIR,TEXT,FILE,CARD, empty for all",
+		},
+		"ids": {
+			validateFunc: validator.StringValidationOptional,
+			usage:        "comma separated record IDs, empty for all",
+		},
+	}
+
+	auditParams = CommandParams{
+		"from": {
+			validateFunc: validator.StringValidationOptional,
+			usage:        "YYYY-MM-DD, empty for no limit",
+		},
+		"to": {
+			validateFunc: validator.StringValidationOptional,
+			usage:        "YYYY-MM-DD inclusive, empty for no limit",
+		},
+		"types": {
+			validateFunc: validator.StringValidationOptional,
+			usage:        "comma separated, ex. login,login_failed,data_read, empty for all",
+		},
+	}
+
+	historyVersionParams = CommandParams{
+		"id":      {validateFunc: validator.IntValidation},
+		"version": {validateFunc: validator.IntValidation, usage: "from HISTORY LIST"},
+	}
+
+	folderCdParams = CommandParams{
+		"folder": {validateFunc: validator.IntValidation, usage: "folder ID, 0 for the top level"},
+	}
+
+	folderCreateParams = CommandParams{
+		"name": {validateFunc: validator.StringValidation},
+	}
+
+	folderRenameParams = CommandParams{
+		"id":   {validateFunc: validator.IntValidation},
+		"name": {validateFunc: validator.StringValidation},
+	}
+
+	folderMoveParams = CommandParams{
+		"id":     {validateFunc: validator.IntValidation},
+		"parent": {validateFunc: validator.IntValidation, usage: "folder ID, 0 for the top level"},
+	}
+
+	folderPutParams = CommandParams{
+		"id":     {validateFunc: validator.IntValidation, usage: "record ID"},
+		"folder": {validateFunc: validator.IntValidation, usage: "folder ID, 0 for the top level"},
+	}
+
+	tagsParams = CommandParams{
+		"id":   {validateFunc: validator.IntValidation},
+		"tags": {validateFunc: validator.StringValidation, usage: "comma separated, ex. work,email"},
+	}
+
+	masterPasswordParams = CommandParams{
+		"master_password": {validateFunc: validator.StringValidation},
+	}
+
+	vaultEnableParams = CommandParams{
+		"master_password": {
+			validateFunc: validator.StringValidation,
+			usage:        "encrypts records on this device, different from the account password",
+		},
+		"confirm": {validateFunc: validator.StringValidation, usage: "master password again"},
+	}
+
 	pairParams = CommandParams{
 		"key":  {validateFunc: validator.StringValidation},
 		"pwd":  {validateFunc: validator.StringValidation},
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+)
+
+func (cm *CommandManager) vaultEnableCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+
+	masterPassword := paramsValidated["master_password"].value
+	if masterPassword != paramsValidated["confirm"].value {
+		return errors.New("master passwords do not match")
+	}
+
+	err := cm.dataService.EnableVault(context.Background(), masterPassword)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print("\nEnd-to-end encryption enabled. The master password cannot be recovered, store it safely.\n")
+
+	return cm.vaultSealCommand(dataType, emptyParams)
+}
+
+func (cm *CommandManager) vaultUnlockCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	paramsValidated := cm.validateParams(params)
+	return cm.dataService.UnlockVault(context.Background(), paramsValidated["master_password"].value)
+}
+
+func (cm *CommandManager) vaultSealCommand(dataType models.DataTypeEnum, params CommandParams) error {
+	err := cm.unlockVault()
+	if err != nil {
+		return err
+	}
+
+	sealed, err := cm.dataService.SealRecords(context.Background())
+	fmt.Printf("Records encrypted: %d\n", sealed)
+
+	return err
+}
+
+// unlockVault asks for the master password when the account uses end-to-end
+// encryption and the vault is still locked.
+func (cm *CommandManager) unlockVault() error {
+	locked, err := cm.dataService.VaultLocked(context.Background())
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+
+	return cm.vaultUnlockCommand(models.DataTypeUNDEFINE, masterPasswordParams)
+}
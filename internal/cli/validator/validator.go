@@ -26,6 +26,13 @@ func StringValidation(param string) error {
 	)
 }
 
+func StringValidationOptional(param string) error {
+	return validation.Validate(
+		param,
+		validation.Length(0, 250),
+	)
+}
+
 func StringValidationUnlimit(param string) error {
 	return validation.Validate(
 		param,
@@ -4,18 +4,29 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"os"
 
+	encryptor "github.com/bbquite/go-pass-keeper/internal/encryption"
 	"github.com/caarlos0/env/v11"
 )
 
 const (
 	defRemoteHost     = "localhost:8080"
 	defClientCertPath = "./cert/ca.pem"
+	defCachePath      = "./cache.json"
+
+	cachePassphraseEnv = "CACHE_PASSPHRASE"
 )
 
 type ClientConfig struct {
 	Host         string `json:"host" env:"HOST"`
 	RootCertPath string `json:"root_cert_path" env:"CLIENT_CRT_PATH"`
+	DeviceName   string `json:"device_name" env:"DEVICE_NAME"`
+
+	ClientCertPath string `json:"client_cert_path" env:"CLIENT_CERT_PATH"`
+	ClientKeyPath  string `json:"client_key_path" env:"CLIENT_KEY_PATH"`
+
+	CachePath string `json:"cache_path" env:"CACHE_PATH"`
 }
 
 func (c *ClientConfig) SetENV() error {
@@ -30,6 +41,10 @@ func (c *ClientConfig) SetENV() error {
 func (c *ClientConfig) SetFlags() {
 	flag.StringVar(&c.Host, "h", defRemoteHost, "remote host")
 	flag.StringVar(&c.RootCertPath, "ca", defClientCertPath, "root cert path")
+	flag.StringVar(&c.ClientCertPath, "cert", "", "client certificate for mTLS authentication")
+	flag.StringVar(&c.ClientKeyPath, "key", "", "client certificate key for mTLS authentication")
+	flag.StringVar(&c.DeviceName, "device", "", "device name shown in the session list (default: hostname)")
+	flag.StringVar(&c.CachePath, "cache", defCachePath, "encrypted local cache of records for offline use, disabled when empty")
 	flag.Parse()
 }
 
@@ -37,3 +52,26 @@ func (c *ClientConfig) PrintConfig() []byte {
 	jsonConfig, _ := json.Marshal(c)
 	return jsonConfig
 }
+
+// GetDeviceName returns the configured device name, falling back to the hostname.
+func (c *ClientConfig) GetDeviceName() string {
+	if c.DeviceName != "" {
+		return c.DeviceName
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return hostname
+}
+
+func (c *ClientConfig) GetCachePath() string {
+	return c.CachePath
+}
+
+// GetCachePassphrase reads the cache passphrase from the environment. When it
+// is empty the CLI asks for it.
+func (c *ClientConfig) GetCachePassphrase() (string, error) {
+	return encryptor.ReadSecretEnv(cachePassphraseEnv)
+}
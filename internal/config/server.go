@@ -4,26 +4,109 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
+	encryptor "github.com/bbquite/go-pass-keeper/internal/encryption"
 	"github.com/caarlos0/env/v11"
 )
 
 const (
 	defServerHost   = "localhost:8080"
 	defDatabaseHost = "host=localhost user=postgres password=123 dbname=gopasskeeper sslmode=disable"
-	defSecretKey    = "ytrfedghjfgjkhk123"
-	defCryptoKey    = "01234567890123456789012345678901"
+	defCryptoKeyID  = "1"
+	defKeyProvider  = KeyProviderConfig
+	defKeyEnv       = "CRYPTO_KEYS"
 	defServerKey    = "./cert/server.key"
 	defServerCrt    = "./cert/server.crt"
+	defRefreshTTL   = time.Hour * 24 * 30
+
+	defClientCertIdentity = "cn"
+
+	defJWTIssuer   = "go-pass-keeper"
+	defJWTAudience = "go-pass-keeper"
+	defJWTExpiry   = time.Hour * 3
+
+	defLoginMaxAttempts   = 5
+	defLoginIPMaxAttempts = 20
+	defLoginBaseDelay     = time.Second
+	defLoginLockout       = time.Minute * 15
+	defLoginWindow        = time.Hour
+
+	defArgon2Memory     = 64 * 1024
+	defArgon2Iterations = 3
+	defArgon2Threads    = 2
+
+	defPasswordMinLength  = 10
+	defPasswordMinClasses = 3
+	defPasswordMinEntropy = 50
+
+	defDataVersions   = 10
+	defTrashRetention = time.Hour * 24 * 30
+)
+
+// Key providers selectable with --key-provider.
+const (
+	KeyProviderConfig  = "config"
+	KeyProviderEnv     = "env"
+	KeyProviderFile    = "file"
+	KeyProviderTransit = "transit"
+
+	keyringPassphraseEnv = "KEYRING_PASSPHRASE"
+	transitTokenEnv      = "VAULT_TOKEN"
 )
 
 type ServerConfig struct {
 	Host          string `json:"host" env:"HOST"`
 	DatabaseURI   string `json:"db_host" env:"DH_HOST"`
-	JWTSecret     string `json:"jwt_secret" env:"SECRET_KEY"`
-	CryptoKey     string `json:"crypto_key" env:"CRYPTO_KEY"`
+	JWTSecret     string `json:"-" env:"SECRET_KEY"`
+	CryptoKey     string `json:"-" env:"CRYPTO_KEY"`
 	ServerKeyPath string `json:"server_key" env:"SERVER_KEY_PATH"`
 	ServerCrtPath string `json:"server_crt" env:"SERVER_CRT_PATH"`
+
+	CryptoKeys      string `json:"-" env:"CRYPTO_KEYS"`
+	CryptoActiveKey string `json:"crypto_active_key" env:"CRYPTO_ACTIVE_KEY"`
+	Reencrypt       bool   `json:"-" env:"REENCRYPT"`
+
+	KeyProvider    string `json:"key_provider" env:"KEY_PROVIDER"`
+	KeyEnv         string `json:"key_env" env:"KEY_ENV"`
+	KeyringPath    string `json:"keyring_path" env:"KEYRING_PATH"`
+	KeyringAdd     string `json:"-" env:"KEYRING_ADD"`
+	TransitAddress string `json:"transit_address" env:"VAULT_ADDR"`
+	TransitMount   string `json:"transit_mount" env:"TRANSIT_MOUNT"`
+	TransitKey     string `json:"transit_key" env:"TRANSIT_KEY"`
+	TransitKeys    string `json:"-" env:"TRANSIT_WRAPPED_KEYS"`
+
+	ClientCAPath       string `json:"client_ca" env:"CLIENT_CA_PATH"`
+	ClientCertIdentity string `json:"client_cert_identity" env:"CLIENT_CERT_IDENTITY"`
+
+	JWTKeys      string        `json:"jwt_keys" env:"JWT_KEYS"`
+	JWTActiveKey string        `json:"jwt_active_key" env:"JWT_ACTIVE_KEY"`
+	JWTIssuer    string        `json:"jwt_issuer" env:"JWT_ISSUER"`
+	JWTAudience  string        `json:"jwt_audience" env:"JWT_AUDIENCE"`
+	JWTExpiry    time.Duration `json:"jwt_expiry" env:"JWT_EXPIRY"`
+
+	RefreshTokenTTL time.Duration `json:"refresh_token_ttl" env:"REFRESH_TOKEN_TTL"`
+	AdminToken      string        `json:"-" env:"ADMIN_TOKEN"`
+
+	LoginMaxAttempts   int           `json:"login_max_attempts" env:"LOGIN_MAX_ATTEMPTS"`
+	LoginIPMaxAttempts int           `json:"login_ip_max_attempts" env:"LOGIN_IP_MAX_ATTEMPTS"`
+	LoginBaseDelay     time.Duration `json:"login_base_delay" env:"LOGIN_BASE_DELAY"`
+	LoginLockout       time.Duration `json:"login_lockout" env:"LOGIN_LOCKOUT"`
+	LoginWindow        time.Duration `json:"login_window" env:"LOGIN_WINDOW"`
+
+	Argon2Memory     uint `json:"argon2_memory" env:"ARGON2_MEMORY"`
+	Argon2Iterations uint `json:"argon2_iterations" env:"ARGON2_ITERATIONS"`
+	Argon2Threads    uint `json:"argon2_threads" env:"ARGON2_THREADS"`
+
+	PasswordMinLength  int     `json:"password_min_length" env:"PASSWORD_MIN_LENGTH"`
+	PasswordMinClasses int     `json:"password_min_classes" env:"PASSWORD_MIN_CLASSES"`
+	PasswordMinEntropy float64 `json:"password_min_entropy" env:"PASSWORD_MIN_ENTROPY"`
+	BreachedPasswords  string  `json:"breached_passwords" env:"BREACHED_PASSWORDS_PATH"`
+
+	DataVersions   int           `json:"data_versions" env:"DATA_VERSIONS"`
+	TrashRetention time.Duration `json:"trash_retention" env:"TRASH_RETENTION"`
 }
 
 func (c *ServerConfig) SetENV() error {
@@ -38,10 +121,44 @@ func (c *ServerConfig) SetENV() error {
 func (c *ServerConfig) SetFlags() {
 	flag.StringVar(&c.Host, "h", defServerHost, "server host")
 	flag.StringVar(&c.DatabaseURI, "d", defDatabaseHost, "db host uri")
-	flag.StringVar(&c.JWTSecret, "secret-key", defSecretKey, "secret key for hash")
-	flag.StringVar(&c.CryptoKey, "crypto-key", defCryptoKey, "crypto key")
+	flag.StringVar(&c.JWTSecret, "secret-key", "", "HMAC secret for access tokens, added to the keyring as kid \"secret\"")
+	flag.StringVar(&c.JWTKeys, "jwt-keys", "", "comma separated kid=path list of EdDSA/ES256 PEM keys")
+	flag.StringVar(&c.JWTActiveKey, "jwt-active-key", "", "kid of the key new tokens are signed with (default: first key)")
+	flag.StringVar(&c.JWTIssuer, "jwt-issuer", defJWTIssuer, "access token issuer")
+	flag.StringVar(&c.JWTAudience, "jwt-audience", defJWTAudience, "access token audience")
+	flag.DurationVar(&c.JWTExpiry, "jwt-expiry", defJWTExpiry, "access token lifetime")
+	flag.StringVar(&c.CryptoKey, "crypto-key", "", "crypto key, used as key version \""+defCryptoKeyID+"\" and for data written before key versions")
+	flag.StringVar(&c.CryptoKeys, "crypto-keys", "", "comma separated id=key list of additional crypto keys")
+	flag.StringVar(&c.CryptoActiveKey, "crypto-active-key", "", "id of the crypto key new data is encrypted with (default: first key)")
+	flag.StringVar(&c.KeyProvider, "key-provider", defKeyProvider, "source of the crypto keys: config (--crypto-key flags), env, file or transit")
+	flag.StringVar(&c.KeyEnv, "key-env", defKeyEnv, "environment variable with the id=key list for the env provider, <name>_FILE is read when it is unset")
+	flag.StringVar(&c.KeyringPath, "keyring", "", "encrypted keyring file for the file provider, the passphrase is read from "+keyringPassphraseEnv+" or "+keyringPassphraseEnv+"_FILE")
+	flag.StringVar(&c.KeyringAdd, "keyring-add", "", "add key id (random) or id=key to the keyring file, make it active and exit")
+	flag.StringVar(&c.TransitAddress, "transit-address", "", "Vault Transit compatible API address, the token is read from "+transitTokenEnv+" or "+transitTokenEnv+"_FILE")
+	flag.StringVar(&c.TransitMount, "transit-mount", "", "Transit secrets engine mount path (default: transit)")
+	flag.StringVar(&c.TransitKey, "transit-key", "", "Transit key the crypto keys are wrapped with")
+	flag.StringVar(&c.TransitKeys, "transit-wrapped-keys", "", "comma separated id=ciphertext list of crypto keys wrapped by Transit")
+	flag.BoolVar(&c.Reencrypt, "reencrypt", false, "re-encrypt stored data with the active crypto key and exit")
 	flag.StringVar(&c.ServerKeyPath, "server-key", defServerKey, "path to server key")
 	flag.StringVar(&c.ServerCrtPath, "server-crt", defServerCrt, "path to server crt")
+	flag.StringVar(&c.ClientCAPath, "client-ca", "", "CA for client certificate authentication, mTLS is disabled when empty")
+	flag.StringVar(&c.ClientCertIdentity, "client-cert-identity", defClientCertIdentity, "client certificate field matched against usernames: cn, email, dns or uri")
+	flag.DurationVar(&c.RefreshTokenTTL, "refresh-ttl", defRefreshTTL, "refresh token lifetime")
+	flag.StringVar(&c.AdminToken, "admin-token", "", "token for admin RPCs, admin API is disabled when empty")
+	flag.IntVar(&c.LoginMaxAttempts, "login-max-attempts", defLoginMaxAttempts, "failed logins per username before lockout")
+	flag.IntVar(&c.LoginIPMaxAttempts, "login-ip-max-attempts", defLoginIPMaxAttempts, "failed logins per peer IP before lockout")
+	flag.DurationVar(&c.LoginBaseDelay, "login-base-delay", defLoginBaseDelay, "delay after the first failed login, doubled on each failure")
+	flag.DurationVar(&c.LoginLockout, "login-lockout", defLoginLockout, "lockout duration after too many failed logins")
+	flag.DurationVar(&c.LoginWindow, "login-window", defLoginWindow, "failed logins older than this are forgotten")
+	flag.UintVar(&c.Argon2Memory, "argon2-memory", defArgon2Memory, "argon2id memory cost in KiB")
+	flag.UintVar(&c.Argon2Iterations, "argon2-iterations", defArgon2Iterations, "argon2id iterations")
+	flag.UintVar(&c.Argon2Threads, "argon2-threads", defArgon2Threads, "argon2id parallelism")
+	flag.IntVar(&c.PasswordMinLength, "password-min-length", defPasswordMinLength, "minimum password length")
+	flag.IntVar(&c.PasswordMinClasses, "password-min-classes", defPasswordMinClasses, "minimum number of character classes (lowercase, uppercase, digits, symbols) in a password")
+	flag.Float64Var(&c.PasswordMinEntropy, "password-min-entropy", defPasswordMinEntropy, "minimum estimated password entropy in bits")
+	flag.StringVar(&c.BreachedPasswords, "breached-passwords", "", "directory with SHA-1 prefix files of breached passwords, the check is disabled when empty")
+	flag.IntVar(&c.DataVersions, "data-versions", defDataVersions, "previous versions kept per record, history is disabled with 0")
+	flag.DurationVar(&c.TrashRetention, "trash-retention", defTrashRetention, "deleted records are purged after this long in the trash, never with 0")
 	flag.Parse()
 }
 
@@ -62,10 +179,136 @@ func (c *ServerConfig) GetSecretKey() string {
 	return c.JWTSecret
 }
 
+// JWTKeyFile is a PEM signing key file with the kid it is published under.
+type JWTKeyFile struct {
+	ID   string
+	Path string
+}
+
+// GetJWTKeys parses the kid=path list of PEM signing keys, keeping its order.
+func (c *ServerConfig) GetJWTKeys() ([]JWTKeyFile, error) {
+	var result []JWTKeyFile
+
+	for _, item := range strings.Split(c.JWTKeys, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		kid, path, ok := strings.Cut(item, "=")
+		if !ok || kid == "" || path == "" {
+			return nil, fmt.Errorf("invalid jwt key %q, expected kid=path", item)
+		}
+		result = append(result, JWTKeyFile{ID: kid, Path: path})
+	}
+
+	return result, nil
+}
+
+func (c *ServerConfig) GetJWTActiveKey() string {
+	return c.JWTActiveKey
+}
+
+func (c *ServerConfig) GetJWTIssuer() string {
+	return c.JWTIssuer
+}
+
+func (c *ServerConfig) GetJWTAudience() string {
+	return c.JWTAudience
+}
+
+func (c *ServerConfig) GetJWTExpiry() time.Duration {
+	return c.JWTExpiry
+}
+
 func (c *ServerConfig) GetCryptoKey() string {
 	return c.CryptoKey
 }
 
+// CryptoKey is a versioned crypto key from the configuration.
+type CryptoKey struct {
+	ID    string
+	Value string
+}
+
+// GetCryptoKeys returns the additional crypto keys followed by --crypto-key
+// under version "1", keeping their order.
+func (c *ServerConfig) GetCryptoKeys() ([]CryptoKey, error) {
+	var result []CryptoKey
+
+	for _, item := range strings.Split(c.CryptoKeys, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		id, key, ok := strings.Cut(item, "=")
+		if !ok || id == "" || key == "" {
+			return nil, fmt.Errorf("invalid crypto key, expected id=key")
+		}
+		result = append(result, CryptoKey{ID: id, Value: key})
+	}
+
+	if c.CryptoKey != "" {
+		result = append(result, CryptoKey{ID: defCryptoKeyID, Value: c.CryptoKey})
+	}
+
+	return result, nil
+}
+
+func (c *ServerConfig) GetCryptoActiveKey() string {
+	return c.CryptoActiveKey
+}
+
+func (c *ServerConfig) GetKeyProvider() string {
+	return c.KeyProvider
+}
+
+func (c *ServerConfig) GetKeyEnv() string {
+	return c.KeyEnv
+}
+
+func (c *ServerConfig) GetKeyringPath() string {
+	return c.KeyringPath
+}
+
+// GetKeyringAdd returns the key ID and, if given, the key to add to the
+// keyring file.
+func (c *ServerConfig) GetKeyringAdd() (string, string) {
+	id, key, _ := strings.Cut(c.KeyringAdd, "=")
+	return id, key
+}
+
+// GetKeyringPassphrase reads the keyring passphrase from the environment, so
+// it does not show up in the process list.
+func (c *ServerConfig) GetKeyringPassphrase() (string, error) {
+	return encryptor.ReadSecretEnv(keyringPassphraseEnv)
+}
+
+func (c *ServerConfig) GetTransitAddress() string {
+	return c.TransitAddress
+}
+
+func (c *ServerConfig) GetTransitMount() string {
+	return c.TransitMount
+}
+
+func (c *ServerConfig) GetTransitKey() string {
+	return c.TransitKey
+}
+
+func (c *ServerConfig) GetTransitWrappedKeys() ([]encryptor.WrappedKey, error) {
+	return encryptor.ParseWrappedKeyList(c.TransitKeys)
+}
+
+func (c *ServerConfig) GetTransitToken() (string, error) {
+	return encryptor.ReadSecretEnv(transitTokenEnv)
+}
+
+func (c *ServerConfig) GetReencrypt() bool {
+	return c.Reencrypt
+}
+
 func (c *ServerConfig) GetServerKeyPath() string {
 	return c.ServerKeyPath
 }
@@ -73,3 +316,84 @@ func (c *ServerConfig) GetServerKeyPath() string {
 func (c *ServerConfig) GetServerCrtPath() string {
 	return c.ServerCrtPath
 }
+
+func (c *ServerConfig) GetClientCAPath() string {
+	return c.ClientCAPath
+}
+
+func (c *ServerConfig) GetClientCertIdentity() string {
+	return c.ClientCertIdentity
+}
+
+func (c *ServerConfig) GetRefreshTokenTTL() time.Duration {
+	return c.RefreshTokenTTL
+}
+
+func (c *ServerConfig) GetAdminToken() string {
+	return c.AdminToken
+}
+
+func (c *ServerConfig) GetLoginMaxAttempts() int {
+	return c.LoginMaxAttempts
+}
+
+func (c *ServerConfig) GetLoginIPMaxAttempts() int {
+	return c.LoginIPMaxAttempts
+}
+
+func (c *ServerConfig) GetLoginBaseDelay() time.Duration {
+	return c.LoginBaseDelay
+}
+
+func (c *ServerConfig) GetLoginLockout() time.Duration {
+	return c.LoginLockout
+}
+
+func (c *ServerConfig) GetLoginWindow() time.Duration {
+	return c.LoginWindow
+}
+
+func (c *ServerConfig) GetArgon2Memory() (uint32, error) {
+	if c.Argon2Memory > math.MaxUint32 {
+		return 0, fmt.Errorf("argon2 memory %d KiB is out of range", c.Argon2Memory)
+	}
+	return uint32(c.Argon2Memory), nil
+}
+
+func (c *ServerConfig) GetArgon2Iterations() (uint32, error) {
+	if c.Argon2Iterations > math.MaxUint32 {
+		return 0, fmt.Errorf("argon2 iterations %d is out of range", c.Argon2Iterations)
+	}
+	return uint32(c.Argon2Iterations), nil
+}
+
+func (c *ServerConfig) GetArgon2Threads() (uint8, error) {
+	if c.Argon2Threads > math.MaxUint8 {
+		return 0, fmt.Errorf("argon2 threads %d is out of range 1-%d", c.Argon2Threads, math.MaxUint8)
+	}
+	return uint8(c.Argon2Threads), nil
+}
+
+func (c *ServerConfig) GetPasswordMinLength() int {
+	return c.PasswordMinLength
+}
+
+func (c *ServerConfig) GetPasswordMinClasses() int {
+	return c.PasswordMinClasses
+}
+
+func (c *ServerConfig) GetPasswordMinEntropy() float64 {
+	return c.PasswordMinEntropy
+}
+
+func (c *ServerConfig) GetBreachedPasswordsPath() string {
+	return c.BreachedPasswords
+}
+
+func (c *ServerConfig) GetDataVersions() int {
+	return c.DataVersions
+}
+
+func (c *ServerConfig) GetTrashRetention() time.Duration {
+	return c.TrashRetention
+}
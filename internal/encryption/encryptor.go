@@ -5,27 +5,104 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 )
 
+// keyIDSeparator separates the key version from the base64 ciphertext. It is
+// not part of the base64 alphabet, so versioned values are unambiguous.
+const keyIDSeparator = ":"
+
+var ErrUnknownKeyVersion = errors.New("unknown encryption key version")
+
+// Key is a versioned AES key.
+type Key struct {
+	ID    string
+	Value []byte
+}
+
+// Encryptor seals values with AES-GCM. Values produced with a versioned active
+// key are prefixed with "<key ID>:", so any configured key can open them.
+// Unprefixed values are opened with the legacy key.
 type Encryptor struct {
-	key []byte
+	keys     map[string][]byte
+	activeID string
+	legacy   []byte
 }
 
+// NewEncryptor returns an encryptor with a single unversioned key.
 func NewEncryptor(key []byte) *Encryptor {
-	return &Encryptor{key: key}
+	return &Encryptor{legacy: key}
+}
+
+// NewKeyringEncryptor returns an encryptor that seals with the active key and
+// opens values of every key in keys. legacy opens unprefixed values written
+// before key versions existed and may be nil.
+func NewKeyringEncryptor(activeID string, legacy []byte, keys ...Key) (*Encryptor, error) {
+	e := &Encryptor{
+		keys:     make(map[string][]byte, len(keys)),
+		activeID: activeID,
+		legacy:   legacy,
+	}
+
+	if legacy != nil {
+		if err := ValidateKeyLength(legacy); err != nil {
+			return nil, fmt.Errorf("legacy key: %w", err)
+		}
+	}
+
+	for _, key := range keys {
+		if key.ID == "" || strings.Contains(key.ID, keyIDSeparator) {
+			return nil, fmt.Errorf("invalid encryption key ID %q", key.ID)
+		}
+		if err := ValidateKeyLength(key.Value); err != nil {
+			return nil, fmt.Errorf("key %q: %w", key.ID, err)
+		}
+		if _, ok := e.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate encryption key ID %q", key.ID)
+		}
+		e.keys[key.ID] = key.Value
+	}
+
+	if _, ok := e.keys[activeID]; !ok {
+		return nil, fmt.Errorf("%w: active key %q", ErrUnknownKeyVersion, activeID)
+	}
+
+	return e, nil
+}
+
+// ActiveKeyID returns the version of the key new values are sealed with.
+func (e *Encryptor) ActiveKeyID() string {
+	return e.activeID
+}
+
+// IsActive reports whether the value was sealed with the active key.
+func (e *Encryptor) IsActive(ciphertext string) bool {
+	keyID, _, ok := strings.Cut(ciphertext, keyIDSeparator)
+	return ok && keyID == e.activeID
 }
 
 func (e *Encryptor) Encrypt(plaintext string) (string, error) {
-	block, err := aes.NewCipher(e.key)
-	if err != nil {
-		return "", fmt.Errorf("creating cipher error: %w", err)
+	return e.EncryptWithAD(plaintext, nil)
+}
+
+func (e *Encryptor) Decrypt(ciphertext string) (string, error) {
+	return e.DecryptWithAD(ciphertext, nil)
+}
+
+// EncryptWithAD seals the plaintext bound to additionalData: the value only
+// decrypts when the same additional data is passed to DecryptWithAD.
+func (e *Encryptor) EncryptWithAD(plaintext string, additionalData []byte) (string, error) {
+	key := e.legacy
+	if e.activeID != "" {
+		key = e.keys[e.activeID]
 	}
 
-	aesGCM, err := cipher.NewGCM(block)
+	aesGCM, err := newGCM(key)
 	if err != nil {
-		return "", fmt.Errorf("creating GCM error: %w", err)
+		return "", err
 	}
 
 	nonce := make([]byte, aesGCM.NonceSize())
@@ -33,24 +110,34 @@ func (e *Encryptor) Encrypt(plaintext string) (string, error) {
 		return "", fmt.Errorf("nonce generation error: %w", err)
 	}
 
-	ciphertext := aesGCM.Seal(nonce, nonce, []byte(plaintext), nil)
-	return base64.StdEncoding.EncodeToString(ciphertext), nil
+	ciphertext := base64.StdEncoding.EncodeToString(aesGCM.Seal(nonce, nonce, []byte(plaintext), additionalData))
+	if e.activeID != "" {
+		return e.activeID + keyIDSeparator + ciphertext, nil
+	}
+	return ciphertext, nil
 }
 
-func (e *Encryptor) Decrypt(ciphertext string) (string, error) {
+func (e *Encryptor) DecryptWithAD(ciphertext string, additionalData []byte) (string, error) {
+	key := e.legacy
+	if keyID, value, ok := strings.Cut(ciphertext, keyIDSeparator); ok {
+		key, ok = e.keys[keyID]
+		if !ok {
+			return "", fmt.Errorf("%w: %q", ErrUnknownKeyVersion, keyID)
+		}
+		ciphertext = value
+	}
+	if key == nil {
+		return "", fmt.Errorf("%w: no legacy key for unversioned data", ErrUnknownKeyVersion)
+	}
+
 	data, err := base64.StdEncoding.DecodeString(ciphertext)
 	if err != nil {
 		return "", fmt.Errorf("decoding base64 error: %w", err)
 	}
 
-	block, err := aes.NewCipher(e.key)
+	aesGCM, err := newGCM(key)
 	if err != nil {
-		return "", fmt.Errorf("creating cipher error: %w", err)
-	}
-
-	aesGCM, err := cipher.NewGCM(block)
-	if err != nil {
-		return "", fmt.Errorf("creating GCM error: %w", err)
+		return "", err
 	}
 
 	nonceSize := aesGCM.NonceSize()
@@ -59,10 +146,24 @@ func (e *Encryptor) Decrypt(ciphertext string) (string, error) {
 	}
 
 	nonce, ciphertextData := data[:nonceSize], data[nonceSize:]
-	plaintext, err := aesGCM.Open(nil, nonce, ciphertextData, nil)
+	plaintext, err := aesGCM.Open(nil, nonce, ciphertextData, additionalData)
 	if err != nil {
 		return "", fmt.Errorf("data decryption error: %w", err)
 	}
 
 	return string(plaintext), nil
 }
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher error: %w", err)
+	}
+
+	aesGCM, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCM error: %w", err)
+	}
+
+	return aesGCM, nil
+}
@@ -0,0 +1,165 @@
+package encryptor
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LegacyKeyID is the key version that also opens values written before key
+// versions existed.
+const LegacyKeyID = "1"
+
+// base64KeyPrefix marks a key given as base64 instead of raw bytes.
+const base64KeyPrefix = "base64:"
+
+var ErrInvalidKeyLength = errors.New("invalid encryption key length")
+
+// Keyring is the set of master keys loaded from a KeyProvider. An empty
+// ActiveID leaves the choice to the caller.
+type Keyring struct {
+	ActiveID string
+	Keys     []Key
+}
+
+// KeyProvider loads the master keys from a key management backend.
+type KeyProvider interface {
+	LoadKeys(ctx context.Context) (Keyring, error)
+}
+
+// LoadEncryptor builds the master encryptor from the keys of provider. The
+// active key is activeID if set, then the active key of the keyring, then the
+// first key. Key lengths are checked here, so a bad key fails at startup.
+func LoadEncryptor(ctx context.Context, provider KeyProvider, activeID string) (*Encryptor, error) {
+	keyring, err := provider.LoadKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(keyring.Keys) == 0 {
+		return nil, fmt.Errorf("no encryption keys configured")
+	}
+
+	if activeID == "" {
+		activeID = keyring.ActiveID
+	}
+	if activeID == "" {
+		activeID = keyring.Keys[0].ID
+	}
+
+	var legacy []byte
+	for _, key := range keyring.Keys {
+		if key.ID == LegacyKeyID {
+			legacy = key.Value
+		}
+	}
+
+	return NewKeyringEncryptor(activeID, legacy, keyring.Keys...)
+}
+
+// ValidateKeyLength checks that key is an AES-128, AES-192 or AES-256 key.
+func ValidateKeyLength(key []byte) error {
+	switch len(key) {
+	case 16, 24, 32:
+		return nil
+	default:
+		return fmt.Errorf("%w: %d bytes, expected 16, 24 or 32", ErrInvalidKeyLength, len(key))
+	}
+}
+
+// StaticKeyProvider returns keys that are already loaded, e.g. from flags.
+type StaticKeyProvider struct {
+	Keyring Keyring
+}
+
+func (p *StaticKeyProvider) LoadKeys(_ context.Context) (Keyring, error) {
+	return p.Keyring, nil
+}
+
+// EnvKeyProvider reads a comma separated id=key list from an environment
+// variable or, when it is unset, from the file named by <Name>_FILE, as
+// container secrets are usually mounted. Keys are raw strings or base64 with
+// the "base64:" prefix.
+type EnvKeyProvider struct {
+	Name string
+}
+
+func NewEnvKeyProvider(name string) *EnvKeyProvider {
+	return &EnvKeyProvider{Name: name}
+}
+
+func (p *EnvKeyProvider) LoadKeys(_ context.Context) (Keyring, error) {
+	value, err := ReadSecretEnv(p.Name)
+	if err != nil {
+		return Keyring{}, err
+	}
+	if value == "" {
+		return Keyring{}, fmt.Errorf("%s or %s_FILE is not set", p.Name, p.Name)
+	}
+
+	keys, err := ParseKeyList(value)
+	if err != nil {
+		return Keyring{}, fmt.Errorf("%s: %w", p.Name, err)
+	}
+	return Keyring{Keys: keys}, nil
+}
+
+// ReadSecretEnv returns the value of the environment variable name or, when it
+// is unset, the trimmed content of the file named by name_FILE.
+func ReadSecretEnv(name string) (string, error) {
+	if value, ok := os.LookupEnv(name); ok {
+		return value, nil
+	}
+
+	path, ok := os.LookupEnv(name + "_FILE")
+	if !ok || path == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading %s_FILE error: %w", name, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// ParseKeyList parses a comma separated id=key list, keeping its order.
+func ParseKeyList(value string) ([]Key, error) {
+	var result []Key
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		id, key, ok := strings.Cut(item, "=")
+		if !ok || id == "" || key == "" {
+			return nil, fmt.Errorf("invalid crypto key, expected id=key")
+		}
+
+		decoded, err := DecodeKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("crypto key %q: %w", id, err)
+		}
+		result = append(result, Key{ID: id, Value: decoded})
+	}
+
+	return result, nil
+}
+
+// DecodeKey returns the bytes of a raw or "base64:" prefixed key.
+func DecodeKey(key string) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(key, base64KeyPrefix)
+	if !ok {
+		return []byte(key), nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decoding base64 error: %w", err)
+	}
+	return decoded, nil
+}
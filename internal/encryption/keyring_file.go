@@ -0,0 +1,92 @@
+package encryptor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	keyringKeyLength      = 32
+	keyringAdditionalData = "go-pass-keeper/keyring"
+)
+
+type keyringContent struct {
+	ActiveID string         `json:"active"`
+	Keys     []keyringEntry `json:"keys"`
+}
+
+type keyringEntry struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// FileKeyProvider loads the master keys from a passphrase encrypted keyring
+// file, written by SaveKeyringFile.
+type FileKeyProvider struct {
+	path       string
+	passphrase string
+}
+
+func NewFileKeyProvider(path string, passphrase string) *FileKeyProvider {
+	return &FileKeyProvider{path: path, passphrase: passphrase}
+}
+
+func (p *FileKeyProvider) LoadKeys(_ context.Context) (Keyring, error) {
+	return ReadKeyringFile(p.path, p.passphrase)
+}
+
+// ReadKeyringFile opens the keyring file with the passphrase.
+func ReadKeyringFile(path string, passphrase string) (Keyring, error) {
+	plaintext, err := ReadPassphraseFile(path, passphrase, keyringAdditionalData)
+	if err != nil {
+		return Keyring{}, fmt.Errorf("keyring: %w", err)
+	}
+
+	var content keyringContent
+	err = json.Unmarshal(plaintext, &content)
+	if err != nil {
+		return Keyring{}, fmt.Errorf("parsing keyring error: %w", err)
+	}
+
+	result := Keyring{ActiveID: content.ActiveID}
+	for _, item := range content.Keys {
+		value, err := base64.StdEncoding.DecodeString(item.Key)
+		if err != nil {
+			return Keyring{}, fmt.Errorf("keyring key %q: %w", item.ID, err)
+		}
+		result.Keys = append(result.Keys, Key{ID: item.ID, Value: value})
+	}
+
+	return result, nil
+}
+
+// SaveKeyringFile seals the keyring with the passphrase and writes it to path.
+func SaveKeyringFile(path string, passphrase string, keyring Keyring) error {
+	content := keyringContent{ActiveID: keyring.ActiveID}
+	for _, item := range keyring.Keys {
+		err := ValidateKeyLength(item.Value)
+		if err != nil {
+			return fmt.Errorf("key %q: %w", item.ID, err)
+		}
+		content.Keys = append(content.Keys, keyringEntry{ID: item.ID, Key: base64.StdEncoding.EncodeToString(item.Value)})
+	}
+
+	plaintext, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+
+	return WritePassphraseFile(path, passphrase, keyringAdditionalData, plaintext)
+}
+
+// GenerateKey returns a random AES-256 key.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, keyringKeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,145 @@
+package encryptor
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	passphraseFileVersion = 1
+	passphraseKeyLength   = 32
+	passphraseSaltLength  = 16
+	passphraseMemory      = 64 * 1024
+	passphraseMaxMemory   = 1024 * 1024
+	passphraseIterations  = 3
+	passphraseParallelism = 2
+)
+
+var ErrIncorrectPassphrase = errors.New("incorrect passphrase")
+
+// passphraseFile is a file sealed with AES-GCM under a key derived from a
+// passphrase with Argon2id. The derivation parameters are stored alongside,
+// so they can be raised without breaking existing files.
+type passphraseFile struct {
+	Version     int    `json:"version"`
+	Salt        string `json:"salt"`
+	Memory      uint32 `json:"memory"`
+	Iterations  uint32 `json:"iterations"`
+	Parallelism uint8  `json:"parallelism"`
+	Data        string `json:"data"`
+}
+
+// ReadPassphraseFile opens a file written by WritePassphraseFile with the same
+// passphrase and associated data.
+func ReadPassphraseFile(path string, passphrase string, additionalData string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("passphrase is empty")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file passphraseFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s error: %w", path, err)
+	}
+	if file.Version != passphraseFileVersion {
+		return nil, fmt.Errorf("unsupported file version %d", file.Version)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(file.Salt)
+	if err != nil || len(salt) < passphraseSaltLength {
+		return nil, fmt.Errorf("invalid salt in %s", path)
+	}
+	if file.Iterations < 1 || file.Parallelism < 1 || file.Memory > passphraseMaxMemory {
+		return nil, fmt.Errorf("invalid key derivation parameters in %s", path)
+	}
+
+	key := argon2.IDKey([]byte(passphrase), salt, file.Iterations, file.Memory, file.Parallelism, passphraseKeyLength)
+	plaintext, err := NewEncryptor(key).DecryptWithAD(file.Data, []byte(additionalData))
+	if err != nil {
+		return nil, ErrIncorrectPassphrase
+	}
+
+	return []byte(plaintext), nil
+}
+
+// WritePassphraseFile seals plaintext with the passphrase and writes it to
+// path with owner-only permissions. A fresh salt is used on every write. The
+// file is replaced atomically, so a crash leaves either the old or the new
+// content.
+func WritePassphraseFile(path string, passphrase string, additionalData string, plaintext []byte) error {
+	if passphrase == "" {
+		return fmt.Errorf("passphrase is empty")
+	}
+
+	salt := make([]byte, passphraseSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+
+	file := passphraseFile{
+		Version:     passphraseFileVersion,
+		Salt:        base64.StdEncoding.EncodeToString(salt),
+		Memory:      passphraseMemory,
+		Iterations:  passphraseIterations,
+		Parallelism: passphraseParallelism,
+	}
+
+	var err error
+	key := argon2.IDKey([]byte(passphrase), salt, file.Iterations, file.Memory, file.Parallelism, passphraseKeyLength)
+	file.Data, err = NewEncryptor(key).EncryptWithAD(string(plaintext), []byte(additionalData))
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic writes data to a temporary owner-only file next to path,
+// syncs it and renames it over path.
+func writeFileAtomic(path string, data []byte) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	err = tmp.Chmod(0600)
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if err != nil {
+		return err
+	}
+	err = tmp.Sync()
+	if err != nil {
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
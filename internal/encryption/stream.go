@@ -0,0 +1,275 @@
+package encryptor
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// Stream format: a header followed by AES-GCM sealed chunks of StreamChunkSize
+// plaintext bytes. Every stream has its own key, derived from the key and the
+// random header salt with HKDF, so chunk nonces are simply the chunk counter
+// with a final-chunk flag in the last byte. The header is the associated data
+// of every chunk. A stream that does not end with a final chunk, or continues
+// after it, does not decrypt.
+const (
+	StreamChunkSize = 64 * 1024
+
+	streamVersion      = 1
+	streamSaltLength   = 32
+	streamMaxChunkSize = 16 * 1024 * 1024
+	streamKeyInfo      = "go-pass-keeper/stream"
+
+	streamFlagFinal = 1
+)
+
+var streamMagic = []byte("gpks")
+
+var (
+	ErrStreamTruncated = errors.New("encrypted stream is truncated")
+	ErrStreamCorrupted = errors.New("encrypted stream is corrupted")
+)
+
+// streamHeader is magic, version, chunk size and salt.
+const streamHeaderLength = 4 + 1 + 4 + streamSaltLength
+
+func newStreamAEAD(key []byte, salt []byte) (cipher.AEAD, error) {
+	err := ValidateKeyLength(key)
+	if err != nil {
+		return nil, err
+	}
+
+	streamKey := make([]byte, 32)
+	_, err = io.ReadFull(hkdf.New(sha256.New, key, salt, []byte(streamKeyInfo)), streamKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return newGCM(streamKey)
+}
+
+// streamNonce returns the nonce of chunk counter.
+func streamNonce(nonce []byte, counter uint64, final bool) {
+	clear(nonce)
+	binary.BigEndian.PutUint64(nonce[len(nonce)-9:], counter)
+	if final {
+		nonce[len(nonce)-1] = streamFlagFinal
+	}
+}
+
+type encryptWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	header  []byte
+	nonce   []byte
+	buf     []byte
+	out     []byte
+	counter uint64
+	closed  bool
+}
+
+// NewEncryptWriter returns a writer that encrypts everything written to it
+// into w in chunks. Close must be called to write the final chunk; it does
+// not close w.
+func NewEncryptWriter(w io.Writer, key []byte) (io.WriteCloser, error) {
+	salt := make([]byte, streamSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("salt generation error: %w", err)
+	}
+
+	aead, err := newStreamAEAD(key, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, streamHeaderLength)
+	header = append(header, streamMagic...)
+	header = append(header, streamVersion)
+	header = binary.BigEndian.AppendUint32(header, StreamChunkSize)
+	header = append(header, salt...)
+
+	_, err = w.Write(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptWriter{
+		w:      w,
+		aead:   aead,
+		header: header,
+		nonce:  make([]byte, aead.NonceSize()),
+		buf:    make([]byte, 0, StreamChunkSize),
+		out:    make([]byte, 0, StreamChunkSize+aead.Overhead()),
+	}, nil
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	if e.closed {
+		return 0, errors.New("write to closed encrypt writer")
+	}
+
+	written := 0
+	for len(p) > 0 {
+		// A full chunk is only sealed once more data arrives, since the last
+		// one has to carry the final flag.
+		if len(e.buf) == StreamChunkSize {
+			err := e.flush(false)
+			if err != nil {
+				return written, err
+			}
+		}
+
+		n := copy(e.buf[len(e.buf):StreamChunkSize], p)
+		e.buf = e.buf[:len(e.buf)+n]
+		p = p[n:]
+		written += n
+	}
+
+	return written, nil
+}
+
+// Close writes the final chunk, which is empty for an empty stream.
+func (e *encryptWriter) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	return e.flush(true)
+}
+
+func (e *encryptWriter) flush(final bool) error {
+	if e.counter == 1<<64-1 {
+		return errors.New("encrypted stream is too long")
+	}
+
+	streamNonce(e.nonce, e.counter, final)
+	e.out = e.aead.Seal(e.out[:0], e.nonce, e.buf, e.header)
+	e.counter++
+	e.buf = e.buf[:0]
+
+	_, err := e.w.Write(e.out)
+	return err
+}
+
+type decryptReader struct {
+	r         io.Reader
+	aead      cipher.AEAD
+	header    []byte
+	nonce     []byte
+	chunk     []byte
+	buf       []byte
+	plaintext []byte
+	counter   uint64
+	final     bool
+	err       error
+}
+
+// NewDecryptReader returns a reader of the plaintext of a stream written by
+// NewEncryptWriter. Plaintext is only returned after its chunk authenticated;
+// a truncated stream ends with ErrStreamTruncated instead of io.EOF.
+func NewDecryptReader(r io.Reader, key []byte) (io.Reader, error) {
+	header := make([]byte, streamHeaderLength)
+	_, err := io.ReadFull(r, header)
+	if err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, ErrStreamTruncated
+		}
+		return nil, err
+	}
+
+	if !bytes.Equal(header[:len(streamMagic)], streamMagic) || header[len(streamMagic)] != streamVersion {
+		return nil, fmt.Errorf("%w: unknown header", ErrStreamCorrupted)
+	}
+	chunkSize := binary.BigEndian.Uint32(header[len(streamMagic)+1:])
+	if chunkSize == 0 || chunkSize > streamMaxChunkSize {
+		return nil, fmt.Errorf("%w: invalid chunk size %d", ErrStreamCorrupted, chunkSize)
+	}
+
+	aead, err := newStreamAEAD(key, header[streamHeaderLength-streamSaltLength:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptReader{
+		r:      r,
+		aead:   aead,
+		header: header,
+		nonce:  make([]byte, aead.NonceSize()),
+		chunk:  make([]byte, int(chunkSize)+aead.Overhead()),
+		buf:    make([]byte, 0, chunkSize),
+	}, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.plaintext) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		d.err = d.next()
+	}
+
+	n := copy(p, d.plaintext)
+	d.plaintext = d.plaintext[n:]
+	return n, nil
+}
+
+// next reads and opens the following chunk. Once the final chunk is read it
+// checks that nothing follows and returns io.EOF.
+func (d *decryptReader) next() error {
+	if d.final {
+		var extra [1]byte
+		n, err := io.ReadFull(d.r, extra[:])
+		if n > 0 {
+			return fmt.Errorf("%w: data after the final chunk", ErrStreamCorrupted)
+		}
+		if errors.Is(err, io.EOF) {
+			return io.EOF
+		}
+		return err
+	}
+
+	n, err := io.ReadFull(d.r, d.chunk)
+	switch {
+	case errors.Is(err, io.EOF):
+		return ErrStreamTruncated
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		// Only the final chunk may be short.
+		return d.open(d.chunk[:n], true)
+	case err != nil:
+		return err
+	}
+
+	// A full chunk is the final one when the stream length is a multiple of
+	// the chunk size.
+	err = d.open(d.chunk, false)
+	if err != nil {
+		err = d.open(d.chunk, true)
+	}
+	return err
+}
+
+func (d *decryptReader) open(chunk []byte, final bool) error {
+	streamNonce(d.nonce, d.counter, final)
+
+	// Opening into a separate buffer keeps the chunk intact for a retry as
+	// the final chunk.
+	plaintext, err := d.aead.Open(d.buf[:0], d.nonce, chunk, d.header)
+	if err != nil {
+		if final {
+			return fmt.Errorf("%w: chunk %d", ErrStreamCorrupted, d.counter)
+		}
+		return err
+	}
+
+	d.counter++
+	d.final = final
+	d.plaintext = plaintext
+	return nil
+}
@@ -0,0 +1,236 @@
+package encryptor
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func testStreamKey(t *testing.T) []byte {
+	t.Helper()
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func encryptStream(t *testing.T, key []byte, plaintext []byte) []byte {
+	t.Helper()
+
+	var out bytes.Buffer
+	w, err := NewEncryptWriter(&out, key)
+	if err != nil {
+		t.Fatalf("NewEncryptWriter: %v", err)
+	}
+	if _, err = w.Write(plaintext); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return out.Bytes()
+}
+
+func decryptStream(key []byte, stream []byte) ([]byte, error) {
+	r, err := NewDecryptReader(bytes.NewReader(stream), key)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+// sealStream builds a stream with explicit chunks and final flags, for
+// streams NewEncryptWriter never writes.
+func sealStream(t *testing.T, key []byte, chunks [][]byte, finals []bool) []byte {
+	t.Helper()
+
+	salt := make([]byte, streamSaltLength)
+	header := append([]byte{}, streamMagic...)
+	header = append(header, streamVersion)
+	header = binary.BigEndian.AppendUint32(header, StreamChunkSize)
+	header = append(header, salt...)
+
+	aead, err := newStreamAEAD(key, salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream := append([]byte{}, header...)
+	nonce := make([]byte, aead.NonceSize())
+	for i, chunk := range chunks {
+		streamNonce(nonce, uint64(i), finals[i])
+		stream = aead.Seal(stream, nonce, chunk, header)
+	}
+	return stream
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	key := testStreamKey(t)
+
+	for _, size := range []int{0, 1, StreamChunkSize - 1, StreamChunkSize, StreamChunkSize + 1, 3*StreamChunkSize + 17} {
+		plaintext := make([]byte, size)
+		if _, err := rand.Read(plaintext); err != nil {
+			t.Fatal(err)
+		}
+
+		stream := encryptStream(t, key, plaintext)
+		chunks := size/StreamChunkSize + 1
+		if size > 0 && size%StreamChunkSize == 0 {
+			chunks--
+		}
+		wantLength := streamHeaderLength + size + chunks*16
+		if len(stream) != wantLength {
+			t.Errorf("size %d: stream length %d, want %d", size, len(stream), wantLength)
+		}
+
+		got, err := decryptStream(key, stream)
+		if err != nil {
+			t.Fatalf("size %d: decrypt: %v", size, err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Fatalf("size %d: plaintext mismatch", size)
+		}
+
+		r, err := NewDecryptReader(iotest.OneByteReader(bytes.NewReader(stream)), key)
+		if err != nil {
+			t.Fatalf("size %d: NewDecryptReader: %v", size, err)
+		}
+		got, err = io.ReadAll(iotest.OneByteReader(r))
+		if err != nil || !bytes.Equal(got, plaintext) {
+			t.Fatalf("size %d: byte-wise decrypt mismatch: %v", size, err)
+		}
+	}
+}
+
+func TestStreamSmallWrites(t *testing.T) {
+	key := testStreamKey(t)
+	plaintext := bytes.Repeat([]byte("0123456789"), StreamChunkSize/5)
+
+	var out bytes.Buffer
+	w, err := NewEncryptWriter(&out, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for rest := plaintext; len(rest) > 0; {
+		n := min(len(rest), 1000)
+		if _, err = w.Write(rest[:n]); err != nil {
+			t.Fatal(err)
+		}
+		rest = rest[n:]
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := decryptStream(key, out.Bytes())
+	if err != nil || !bytes.Equal(got, plaintext) {
+		t.Fatalf("decrypt mismatch: %v", err)
+	}
+}
+
+func TestStreamRejectsTampering(t *testing.T) {
+	key := testStreamKey(t)
+	chunk := bytes.Repeat([]byte{'a'}, StreamChunkSize)
+	sealedChunk := StreamChunkSize + 16
+
+	twoChunks := encryptStream(t, key, make([]byte, StreamChunkSize+1))
+	threeChunks := encryptStream(t, key, make([]byte, 2*StreamChunkSize+1))
+
+	reordered := append([]byte{}, threeChunks[:streamHeaderLength]...)
+	reordered = append(reordered, threeChunks[streamHeaderLength+sealedChunk:streamHeaderLength+2*sealedChunk]...)
+	reordered = append(reordered, threeChunks[streamHeaderLength:streamHeaderLength+sealedChunk]...)
+	reordered = append(reordered, threeChunks[streamHeaderLength+2*sealedChunk:]...)
+
+	tests := []struct {
+		name    string
+		stream  []byte
+		wantErr error
+	}{
+		{
+			name:    "empty",
+			stream:  nil,
+			wantErr: ErrStreamTruncated,
+		},
+		{
+			name:    "header only",
+			stream:  twoChunks[:streamHeaderLength],
+			wantErr: ErrStreamTruncated,
+		},
+		{
+			name:    "final chunk dropped",
+			stream:  twoChunks[:streamHeaderLength+sealedChunk],
+			wantErr: ErrStreamTruncated,
+		},
+		{
+			name:    "cut inside a chunk",
+			stream:  twoChunks[:streamHeaderLength+sealedChunk/2],
+			wantErr: ErrStreamCorrupted,
+		},
+		{
+			name:    "reordered chunks",
+			stream:  reordered,
+			wantErr: ErrStreamCorrupted,
+		},
+		{
+			name:    "last chunk not final",
+			stream:  sealStream(t, key, [][]byte{chunk, []byte("tail")}, []bool{false, false}),
+			wantErr: ErrStreamCorrupted,
+		},
+		{
+			name:    "full last chunk not final",
+			stream:  sealStream(t, key, [][]byte{chunk, chunk}, []bool{false, false}),
+			wantErr: ErrStreamTruncated,
+		},
+		{
+			name:    "final chunk followed by a chunk",
+			stream:  sealStream(t, key, [][]byte{chunk, []byte("tail")}, []bool{true, true}),
+			wantErr: ErrStreamCorrupted,
+		},
+		{
+			name:    "trailing data",
+			stream:  append(append([]byte{}, twoChunks...), 0),
+			wantErr: ErrStreamCorrupted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decryptStream(key, tt.stream)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("decrypt error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStreamRejectsWrongKey(t *testing.T) {
+	stream := encryptStream(t, testStreamKey(t), []byte("secret"))
+
+	_, err := decryptStream(testStreamKey(t), stream)
+	if !errors.Is(err, ErrStreamCorrupted) {
+		t.Fatalf("decrypt error = %v, want %v", err, ErrStreamCorrupted)
+	}
+}
+
+func TestStreamRejectsModifiedHeader(t *testing.T) {
+	key := testStreamKey(t)
+	stream := encryptStream(t, key, []byte("secret"))
+
+	badMagic := append([]byte{}, stream...)
+	badMagic[0] ^= 1
+	if _, err := decryptStream(key, badMagic); !errors.Is(err, ErrStreamCorrupted) {
+		t.Errorf("magic: decrypt error = %v, want %v", err, ErrStreamCorrupted)
+	}
+
+	badSalt := append([]byte{}, stream...)
+	badSalt[streamHeaderLength-1] ^= 1
+	if _, err := decryptStream(key, badSalt); !errors.Is(err, ErrStreamCorrupted) {
+		t.Errorf("salt: decrypt error = %v, want %v", err, ErrStreamCorrupted)
+	}
+}
@@ -0,0 +1,143 @@
+package encryptor
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defTransitMount   = "transit"
+	defTransitTimeout = time.Second * 10
+)
+
+// WrappedKey is a master key encrypted by the Transit engine.
+type WrappedKey struct {
+	ID         string
+	Ciphertext string
+}
+
+// TransitKeyProvider unwraps master keys with the decrypt endpoint of a
+// HashiCorp Vault Transit compatible API. The keys are stored wrapped, e.g.
+// from "vault write transit/datakey/wrapped/<key>", and only their plaintext
+// lives in memory.
+type TransitKeyProvider struct {
+	address string
+	token   string
+	mount   string
+	keyName string
+	keys    []WrappedKey
+	client  *http.Client
+}
+
+// NewTransitKeyProvider returns a provider for the Transit key keyName on the
+// server at address. An empty mount defaults to "transit".
+func NewTransitKeyProvider(address string, token string, mount string, keyName string, keys []WrappedKey) *TransitKeyProvider {
+	if mount == "" {
+		mount = defTransitMount
+	}
+
+	return &TransitKeyProvider{
+		address: strings.TrimSuffix(address, "/"),
+		token:   token,
+		mount:   strings.Trim(mount, "/"),
+		keyName: keyName,
+		keys:    keys,
+		client:  &http.Client{Timeout: defTransitTimeout},
+	}
+}
+
+type transitDecryptRequest struct {
+	Ciphertext string `json:"ciphertext"`
+}
+
+type transitDecryptResponse struct {
+	Data struct {
+		Plaintext string `json:"plaintext"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+func (p *TransitKeyProvider) LoadKeys(ctx context.Context) (Keyring, error) {
+	if p.address == "" || p.keyName == "" {
+		return Keyring{}, fmt.Errorf("transit address and key name are required")
+	}
+
+	var result Keyring
+	for _, item := range p.keys {
+		value, err := p.decrypt(ctx, item.Ciphertext)
+		if err != nil {
+			return Keyring{}, fmt.Errorf("unwrapping key %q: %w", item.ID, err)
+		}
+		err = ValidateKeyLength(value)
+		if err != nil {
+			return Keyring{}, fmt.Errorf("unwrapped key %q: %w", item.ID, err)
+		}
+		result.Keys = append(result.Keys, Key{ID: item.ID, Value: value})
+	}
+
+	return result, nil
+}
+
+func (p *TransitKeyProvider) decrypt(ctx context.Context, ciphertext string) ([]byte, error) {
+	body, err := json.Marshal(transitDecryptRequest{Ciphertext: ciphertext})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/%s/decrypt/%s", p.address, p.mount, url.PathEscape(p.keyName))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result transitDecryptResponse
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("transit response %s: %w", resp.Status, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("transit response %s: %s", resp.Status, strings.Join(result.Errors, "; "))
+	}
+
+	value, err := base64.StdEncoding.DecodeString(result.Data.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("decoding plaintext error: %w", err)
+	}
+	return value, nil
+}
+
+// ParseWrappedKeyList parses a comma separated id=ciphertext list, keeping its
+// order.
+func ParseWrappedKeyList(value string) ([]WrappedKey, error) {
+	var result []WrappedKey
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		id, ciphertext, ok := strings.Cut(item, "=")
+		if !ok || id == "" || ciphertext == "" {
+			return nil, fmt.Errorf("invalid wrapped key, expected id=ciphertext")
+		}
+		result = append(result, WrappedKey{ID: id, Ciphertext: ciphertext})
+	}
+
+	return result, nil
+}
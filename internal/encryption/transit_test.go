@@ -0,0 +1,193 @@
+package encryptor
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testTransitToken = "test-token"
+
+// fakeTransit is a Transit decrypt endpoint for the key "pass-keeper" under
+// the mount "transit". wrap registers a plaintext and returns its ciphertext.
+type fakeTransit struct {
+	keys map[string][]byte
+}
+
+func (f *fakeTransit) wrap(plaintext []byte) string {
+	ciphertext := "vault:v1:" + base64.StdEncoding.EncodeToString(append([]byte("wrapped:"), plaintext...))
+	f.keys[ciphertext] = plaintext
+	return ciphertext
+}
+
+func (f *fakeTransit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reply := func(status int, body any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	}
+	fail := func(status int, message string) {
+		reply(status, map[string][]string{"errors": {message}})
+	}
+
+	if r.Method != http.MethodPost || r.URL.Path != "/v1/transit/decrypt/pass-keeper" {
+		fail(http.StatusNotFound, "no handler for route")
+		return
+	}
+	if r.Header.Get("X-Vault-Token") != testTransitToken {
+		fail(http.StatusForbidden, "permission denied")
+		return
+	}
+
+	var req transitDecryptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		fail(http.StatusBadRequest, "invalid request")
+		return
+	}
+	plaintext, ok := f.keys[req.Ciphertext]
+	if !ok {
+		fail(http.StatusBadRequest, "cipher: message authentication failed")
+		return
+	}
+
+	reply(http.StatusOK, map[string]any{
+		"data": map[string]string{"plaintext": base64.StdEncoding.EncodeToString(plaintext)},
+	})
+}
+
+func newFakeTransit(t *testing.T) (*fakeTransit, *httptest.Server) {
+	t.Helper()
+
+	transit := &fakeTransit{keys: make(map[string][]byte)}
+	server := httptest.NewServer(transit)
+	t.Cleanup(server.Close)
+
+	return transit, server
+}
+
+func TestTransitKeyProviderUnwrapsKeys(t *testing.T) {
+	transit, server := newFakeTransit(t)
+
+	first := bytes.Repeat([]byte{1}, 32)
+	second := bytes.Repeat([]byte{2}, 16)
+	provider := NewTransitKeyProvider(server.URL+"/", testTransitToken, "", "pass-keeper", []WrappedKey{
+		{ID: "1", Ciphertext: transit.wrap(first)},
+		{ID: "2", Ciphertext: transit.wrap(second)},
+	})
+
+	keyring, err := provider.LoadKeys(context.Background())
+	if err != nil {
+		t.Fatalf("LoadKeys: %v", err)
+	}
+	if len(keyring.Keys) != 2 {
+		t.Fatalf("got %d keys, want 2", len(keyring.Keys))
+	}
+	for i, want := range []Key{{ID: "1", Value: first}, {ID: "2", Value: second}} {
+		got := keyring.Keys[i]
+		if got.ID != want.ID || !bytes.Equal(got.Value, want.Value) {
+			t.Errorf("key %d = %q %x, want %q %x", i, got.ID, got.Value, want.ID, want.Value)
+		}
+	}
+
+	master, err := LoadEncryptor(context.Background(), provider, "2")
+	if err != nil {
+		t.Fatalf("LoadEncryptor: %v", err)
+	}
+	ciphertext, err := master.Encrypt("secret")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	plaintext, err := master.Decrypt(ciphertext)
+	if err != nil || plaintext != "secret" {
+		t.Fatalf("Decrypt = %q, %v", plaintext, err)
+	}
+}
+
+func TestTransitKeyProviderErrors(t *testing.T) {
+	transit, server := newFakeTransit(t)
+	valid := transit.wrap(bytes.Repeat([]byte{1}, 32))
+
+	tests := []struct {
+		name    string
+		token   string
+		mount   string
+		key     WrappedKey
+		wantErr string
+	}{
+		{
+			name:    "forbidden",
+			token:   "wrong-token",
+			key:     WrappedKey{ID: "1", Ciphertext: valid},
+			wantErr: "403 Forbidden: permission denied",
+		},
+		{
+			name:    "unknown ciphertext",
+			token:   testTransitToken,
+			key:     WrappedKey{ID: "1", Ciphertext: "vault:v1:bm9wZQ=="},
+			wantErr: "400 Bad Request: cipher: message authentication failed",
+		},
+		{
+			name:    "wrong mount",
+			token:   testTransitToken,
+			mount:   "other",
+			key:     WrappedKey{ID: "1", Ciphertext: valid},
+			wantErr: "404 Not Found: no handler for route",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewTransitKeyProvider(server.URL, tt.token, tt.mount, "pass-keeper", []WrappedKey{tt.key})
+
+			_, err := provider.LoadKeys(context.Background())
+			if err == nil {
+				t.Fatal("LoadKeys succeeded, want error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %q does not contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransitKeyProviderNonJSONError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream unavailable", http.StatusBadGateway)
+	}))
+	t.Cleanup(server.Close)
+
+	provider := NewTransitKeyProvider(server.URL, testTransitToken, "", "pass-keeper", []WrappedKey{{ID: "1", Ciphertext: "vault:v1:x"}})
+
+	_, err := provider.LoadKeys(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "502 Bad Gateway") {
+		t.Fatalf("LoadKeys error = %v, want a 502 error", err)
+	}
+}
+
+func TestTransitKeyProviderWrongKeyLength(t *testing.T) {
+	transit, server := newFakeTransit(t)
+
+	provider := NewTransitKeyProvider(server.URL, testTransitToken, "", "pass-keeper", []WrappedKey{
+		{ID: "1", Ciphertext: transit.wrap(bytes.Repeat([]byte{1}, 20))},
+	})
+
+	_, err := provider.LoadKeys(context.Background())
+	if !errors.Is(err, ErrInvalidKeyLength) {
+		t.Fatalf("LoadKeys error = %v, want %v", err, ErrInvalidKeyLength)
+	}
+}
+
+func TestTransitKeyProviderRequiresAddressAndKey(t *testing.T) {
+	provider := NewTransitKeyProvider("", testTransitToken, "", "", nil)
+
+	_, err := provider.LoadKeys(context.Background())
+	if err == nil {
+		t.Fatal("LoadKeys succeeded without address and key name")
+	}
+}
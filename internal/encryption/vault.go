@@ -0,0 +1,94 @@
+package encryptor
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	VaultKeyLength      = 32
+	VaultSaltLength     = 16
+	vaultMinMemory      = 19 * 1024
+	vaultMaxMemory      = 1024 * 1024
+	vaultMaxIterations  = 16
+	vaultMaxParallelism = 16
+
+	// VaultCiphertextPrefix marks values encrypted on the client with the vault key.
+	VaultCiphertextPrefix = "e2e1:"
+
+	vaultVerifierPlaintext = "go-pass-keeper vault"
+)
+
+var ErrInvalidVaultParams = errors.New("invalid vault key derivation parameters")
+
+// ValidateVaultParams checks the derivation parameters against sane bounds,
+// so neither side accepts a downgraded or absurdly expensive setup.
+func ValidateVaultParams(params models.VaultParams) error {
+	salt, err := base64.StdEncoding.DecodeString(params.Salt)
+	if err != nil || len(salt) < VaultSaltLength {
+		return fmt.Errorf("%w: salt must be at least %d bytes", ErrInvalidVaultParams, VaultSaltLength)
+	}
+	if params.Memory < vaultMinMemory || params.Memory > vaultMaxMemory {
+		return fmt.Errorf("%w: memory must be between %d and %d KiB", ErrInvalidVaultParams, vaultMinMemory, vaultMaxMemory)
+	}
+	if params.Iterations < 1 || params.Iterations > vaultMaxIterations {
+		return fmt.Errorf("%w: iterations must be between 1 and %d", ErrInvalidVaultParams, vaultMaxIterations)
+	}
+	if params.Parallelism < 1 || params.Parallelism > vaultMaxParallelism {
+		return fmt.Errorf("%w: parallelism must be between 1 and %d", ErrInvalidVaultParams, vaultMaxParallelism)
+	}
+	if params.Verifier == "" {
+		return fmt.Errorf("%w: verifier required", ErrInvalidVaultParams)
+	}
+	return nil
+}
+
+// DeriveVaultKey derives the vault key from the master password with Argon2id.
+func DeriveVaultKey(masterPassword string, params models.VaultParams) ([]byte, error) {
+	salt, err := base64.StdEncoding.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidVaultParams, err)
+	}
+
+	return argon2.IDKey([]byte(masterPassword), salt, params.Iterations, params.Memory, params.Parallelism, VaultKeyLength), nil
+}
+
+// VaultVerifier seals the known verifier value with the vault key.
+func (e *Encryptor) VaultVerifier() (string, error) {
+	return e.Encrypt(vaultVerifierPlaintext)
+}
+
+// CheckVaultVerifier reports whether verifier was sealed with the same key.
+func (e *Encryptor) CheckVaultVerifier(verifier string) bool {
+	plaintext, err := e.Decrypt(verifier)
+	return err == nil && plaintext == vaultVerifierPlaintext
+}
+
+// SealVaultValue encrypts a value and marks it as vault ciphertext.
+func (e *Encryptor) SealVaultValue(plaintext string) (string, error) {
+	ciphertext, err := e.Encrypt(plaintext)
+	if err != nil {
+		return "", err
+	}
+	return VaultCiphertextPrefix + ciphertext, nil
+}
+
+// OpenVaultValue decrypts vault ciphertext. Values without the prefix were
+// stored before the vault was enabled and are returned as is.
+func (e *Encryptor) OpenVaultValue(value string) (string, error) {
+	ciphertext, ok := strings.CutPrefix(value, VaultCiphertextPrefix)
+	if !ok {
+		return value, nil
+	}
+	return e.Decrypt(ciphertext)
+}
+
+// IsVaultValue reports whether the value is vault ciphertext.
+func IsVaultValue(value string) bool {
+	return strings.HasPrefix(value, VaultCiphertextPrefix)
+}
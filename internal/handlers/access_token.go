@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	serverServices "github.com/bbquite/go-pass-keeper/internal/service/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *GRPCHandler) CreateAccessToken(ctx context.Context, in *pb.CreateAccessTokenRequest) (*pb.CreateAccessTokenResponse, error) {
+	scopes := models.AccessTokenScopes{
+		ReadOnly:  in.GetScopes().GetReadOnly(),
+		RecordIDs: in.GetScopes().GetRecordIds(),
+	}
+	for _, dataType := range in.GetScopes().GetDataTypes() {
+		if dataType == pb.DataTypeEnum_UNKNOWN {
+			return nil, status.Error(codes.InvalidArgument, "unknown data type in scopes")
+		}
+		scopes.DataTypes = append(scopes.DataTypes, models.DataTypeEnum(dataType.String()))
+	}
+
+	expiresIn := time.Duration(in.GetExpiresInDays()) * time.Hour * 24
+
+	token, accessToken, err := h.accessTokenService.CreateAccessToken(ctx, in.GetName(), expiresIn, scopes)
+	if err != nil {
+		if errors.Is(err, serverServices.ErrInvalidTokenRequest) {
+			h.logger.Info(err)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		h.logger.Error(err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.CreateAccessTokenResponse{
+		Token:       token,
+		AccessToken: accessTokenToPB(accessToken),
+	}, nil
+}
+
+func (h *GRPCHandler) ListAccessTokens(ctx context.Context, in *pb.Empty) (*pb.ListAccessTokensResponse, error) {
+	accessTokens, err := h.accessTokenService.ListAccessTokens(ctx)
+	if err != nil {
+		h.logger.Error(err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var result []*pb.AccessToken
+	for _, item := range accessTokens {
+		result = append(result, accessTokenToPB(item))
+	}
+
+	return &pb.ListAccessTokensResponse{
+		AccessTokens: result,
+	}, nil
+}
+
+func (h *GRPCHandler) RevokeAccessToken(ctx context.Context, in *pb.RevokeAccessTokenRequest) (*pb.Empty, error) {
+	err := h.accessTokenService.RevokeAccessToken(ctx, in.GetId())
+	if err != nil {
+		if errors.Is(err, serverServices.ErrAccessTokenNotFound) {
+			h.logger.Info(err)
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
+		h.logger.Error(err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func accessTokenToPB(accessToken models.AccessToken) *pb.AccessToken {
+	scopes := &pb.AccessTokenScopes{
+		ReadOnly:  accessToken.Scopes.ReadOnly,
+		RecordIds: accessToken.Scopes.RecordIDs,
+	}
+	for _, dataType := range accessToken.Scopes.DataTypes {
+		scopes.DataTypes = append(scopes.DataTypes, pb.DataTypeEnum(pb.DataTypeEnum_value[string(dataType)]))
+	}
+
+	result := &pb.AccessToken{
+		Id:        accessToken.ID,
+		Name:      accessToken.Name,
+		Scopes:    scopes,
+		ExpiresAt: accessToken.ExpiresAt.Format(formatTimeLayout),
+		CreatedAt: accessToken.CreatedAt.Format(formatTimeLayout),
+	}
+	if accessToken.LastUsedAt != nil {
+		result.LastUsedAt = accessToken.LastUsedAt.Format(formatTimeLayout)
+	}
+
+	return result
+}
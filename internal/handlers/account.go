@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"errors"
+
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	serverServices "github.com/bbquite/go-pass-keeper/internal/service/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const exportChunkSize = 64 * 1024
+
+func (h *GRPCHandler) DeleteAccount(ctx context.Context, in *pb.DeleteAccountRequest) (*pb.Empty, error) {
+	err := h.authService.DeleteAccount(ctx, in.GetPassword(), in.GetCode())
+
+	if err != nil {
+		if errors.Is(err, serverServices.ErrIncorrectLoginData) || errors.Is(err, serverServices.ErrIncorrectMfaCode) {
+			h.logger.Info(err)
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, serverServices.ErrLoginLocked) {
+			return nil, h.loginLockedError(ctx, err)
+		}
+
+		h.logger.Error(err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (h *GRPCHandler) ExportAccount(in *pb.Empty, stream grpc.ServerStreamingServer[pb.ExportChunk]) error {
+	w := bufio.NewWriterSize(&exportChunkWriter{stream: stream}, exportChunkSize)
+
+	err := h.dataService.ExportAccount(stream.Context(), w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		h.logger.Error(err)
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+// exportChunkWriter sends every write as a separate ExportChunk message.
+type exportChunkWriter struct {
+	stream grpc.ServerStreamingServer[pb.ExportChunk]
+}
+
+func (w *exportChunkWriter) Write(p []byte) (int, error) {
+	err := w.stream.Send(&pb.ExportChunk{Data: p})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	serverServices "github.com/bbquite/go-pass-keeper/internal/service/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *GRPCHandler) AdminUnlockLogin(ctx context.Context, in *pb.UnlockLoginRequest) (*pb.UnlockLoginResponse, error) {
+	if in.GetUsername() == "" && in.GetIp() == "" {
+		return nil, status.Error(codes.InvalidArgument, "username or ip required")
+	}
+
+	unlocked, err := h.authService.UnlockLogin(ctx, in.GetUsername(), in.GetIp())
+	if err != nil {
+		h.logger.Error(err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.UnlockLoginResponse{Unlocked: unlocked}, nil
+}
+
+// AdminReencryptData re-encrypts stored data with the active crypto key and
+// streams the progress after every batch.
+func (h *GRPCHandler) AdminReencryptData(in *pb.ReencryptRequest, stream pb.PassKeeperService_AdminReencryptDataServer) error {
+	err := h.keyRotationService.Reencrypt(stream.Context(), int(in.GetBatchSize()), in.GetRestart(), func(rotation models.KeyRotation) error {
+		return stream.Send(&pb.ReencryptProgress{
+			KeyId:     rotation.KeyID,
+			Stage:     rotation.Stage,
+			Processed: rotation.Processed,
+			Total:     rotation.Total,
+			Updated:   rotation.Updated,
+			Failed:    rotation.Failed,
+			Done:      rotation.FinishedAt != nil,
+		})
+	})
+	if err != nil {
+		if errors.Is(err, serverServices.ErrReencryptionRunning) {
+			h.logger.Info(err)
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		h.logger.Error(err)
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	serverServices "github.com/bbquite/go-pass-keeper/internal/service/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *GRPCHandler) ListAuditEvents(ctx context.Context, in *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	filter := models.AuditFilter{
+		EventTypes: in.GetEventTypes(),
+		Limit:      int(in.GetPageSize()),
+	}
+
+	var err error
+	filter.From, err = parseFilterTime(in.GetFrom())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid from time, expected "+formatTimeLayout)
+	}
+	filter.To, err = parseFilterTime(in.GetTo())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid to time, expected "+formatTimeLayout)
+	}
+
+	if in.GetPageToken() != "" {
+		filter.BeforeID, err = strconv.ParseUint(in.GetPageToken(), 10, 64)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+
+	events, nextCursor, err := h.auditService.ListAuditEvents(ctx, filter)
+	if err != nil {
+		if errors.Is(err, serverServices.ErrInvalidAuditFilter) {
+			h.logger.Info(err)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		h.logger.Error(err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var result []*pb.AuditEvent
+	for _, item := range events {
+		result = append(result, &pb.AuditEvent{
+			Id:            item.ID,
+			EventType:     item.EventType,
+			RecordId:      item.RecordID,
+			SessionId:     item.SessionID,
+			AccessTokenId: item.AccessTokenID,
+			PeerAddress:   item.PeerAddress,
+			Details:       item.Details,
+			CreatedAt:     item.CreatedAt.Format(formatTimeLayout),
+		})
+	}
+
+	response := &pb.ListAuditEventsResponse{
+		Events: result,
+	}
+	if nextCursor != 0 {
+		response.NextPageToken = strconv.FormatUint(nextCursor, 10)
+	}
+
+	return response, nil
+}
+
+// parseFilterTime parses an optional time in the server time zone.
+func parseFilterTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.ParseInLocation(formatTimeLayout, value, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
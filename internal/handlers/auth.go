@@ -3,18 +3,28 @@ package handlers
 import (
 	"context"
 	"errors"
+	"math"
+	"strconv"
+	"strings"
 
 	"github.com/bbquite/go-pass-keeper/internal/models"
 	pb "github.com/bbquite/go-pass-keeper/internal/proto"
 	serverServices "github.com/bbquite/go-pass-keeper/internal/service/server"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+// passwordPolicyDomain is the ErrorInfo domain of password policy violations.
+const passwordPolicyDomain = "go-pass-keeper"
+
 func (h *GRPCHandler) RegisterUser(ctx context.Context, in *pb.UserAccountRequest) (*pb.UserAccountResponse, error) {
 	userData := models.UserAccountData{
-		Username: in.Username,
-		Password: in.Password,
+		Username:   in.Username,
+		Password:   in.Password,
+		DeviceName: in.GetDeviceName(),
 	}
 
 	token, err := h.authService.RegisterUser(ctx, &userData)
@@ -24,20 +34,25 @@ func (h *GRPCHandler) RegisterUser(ctx context.Context, in *pb.UserAccountReques
 			h.logger.Info(err)
 			return nil, status.Error(codes.AlreadyExists, "user already exists")
 		}
+		if errors.Is(err, serverServices.ErrWeakPassword) {
+			return nil, h.weakPasswordError(err)
+		}
 
 		h.logger.Error(err)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return &pb.UserAccountResponse{
-		Token: token.Token,
+		Token:        token.Token,
+		RefreshToken: token.RefreshToken,
 	}, nil
 }
 
 func (h *GRPCHandler) AuthUser(ctx context.Context, in *pb.UserAccountRequest) (*pb.UserAccountResponse, error) {
 	userData := models.UserAccountData{
-		Username: in.Username,
-		Password: in.Password,
+		Username:   in.Username,
+		Password:   in.Password,
+		DeviceName: in.GetDeviceName(),
 	}
 
 	token, err := h.authService.AuthUser(ctx, &userData)
@@ -47,12 +62,135 @@ func (h *GRPCHandler) AuthUser(ctx context.Context, in *pb.UserAccountRequest) (
 			h.logger.Info(err)
 			return nil, status.Error(codes.Unauthenticated, "incorrect login or password")
 		}
+		if errors.Is(err, serverServices.ErrLoginLocked) {
+			return nil, h.loginLockedError(ctx, err)
+		}
 
 		h.logger.Error(err)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	if token.MfaToken != "" {
+		return &pb.UserAccountResponse{
+			MfaRequired: true,
+			MfaToken:    token.MfaToken,
+		}, nil
+	}
+
 	return &pb.UserAccountResponse{
-		Token: token.Token,
+		Token:        token.Token,
+		RefreshToken: token.RefreshToken,
 	}, nil
 }
+
+func (h *GRPCHandler) RefreshToken(ctx context.Context, in *pb.RefreshTokenRequest) (*pb.UserAccountResponse, error) {
+	token, err := h.authService.RefreshToken(ctx, in.GetRefreshToken())
+
+	if err != nil {
+		if errors.Is(err, serverServices.ErrInvalidRefreshToken) || errors.Is(err, serverServices.ErrRefreshTokenReused) {
+			h.logger.Info(err)
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		h.logger.Error(err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.UserAccountResponse{
+		Token:        token.Token,
+		RefreshToken: token.RefreshToken,
+	}, nil
+}
+
+func (h *GRPCHandler) Logout(ctx context.Context, in *pb.LogoutRequest) (*pb.Empty, error) {
+	err := h.authService.Logout(ctx, in.GetRefreshToken())
+	if err != nil {
+		if errors.Is(err, serverServices.ErrTokenRequired) {
+			h.logger.Info(err)
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		h.logger.Error(err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (h *GRPCHandler) ChangePassword(ctx context.Context, in *pb.ChangePasswordRequest) (*pb.UserAccountResponse, error) {
+	if in.GetNewPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "new password required")
+	}
+
+	token, err := h.authService.ChangePassword(ctx, in.GetCurrentPassword(), in.GetNewPassword())
+
+	if err != nil {
+		if errors.Is(err, serverServices.ErrIncorrectLoginData) {
+			h.logger.Info(err)
+			return nil, status.Error(codes.PermissionDenied, "incorrect current password")
+		}
+		if errors.Is(err, serverServices.ErrTokenRequired) {
+			h.logger.Info(err)
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, serverServices.ErrLoginLocked) {
+			return nil, h.loginLockedError(ctx, err)
+		}
+		if errors.Is(err, serverServices.ErrWeakPassword) {
+			return nil, h.weakPasswordError(err)
+		}
+
+		h.logger.Error(err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.UserAccountResponse{
+		Token:        token.Token,
+		RefreshToken: token.RefreshToken,
+	}, nil
+}
+
+// weakPasswordError reports a password policy violation as InvalidArgument with
+// a BadRequest detail for the password field and an ErrorInfo naming the rule.
+func (h *GRPCHandler) weakPasswordError(err error) error {
+	h.logger.Info(err)
+
+	var policyErr *serverServices.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	st, detailsErr := status.New(codes.InvalidArgument, policyErr.Reason).WithDetails(
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "password", Description: policyErr.Reason},
+			},
+		},
+		&errdetails.ErrorInfo{
+			Reason: strings.ToUpper(policyErr.Rule),
+			Domain: passwordPolicyDomain,
+		},
+	)
+	if detailsErr != nil {
+		h.logger.Error(detailsErr)
+		return status.Error(codes.InvalidArgument, policyErr.Reason)
+	}
+
+	return st.Err()
+}
+
+// loginLockedError reports a lockout as ResourceExhausted with the number of
+// seconds to wait in the "retry-after" trailer.
+func (h *GRPCHandler) loginLockedError(ctx context.Context, err error) error {
+	h.logger.Info(err)
+
+	var lockedErr *serverServices.LoginLockedError
+	if errors.As(err, &lockedErr) {
+		retryAfter := strconv.Itoa(int(math.Ceil(lockedErr.RetryAfter.Seconds())))
+		if trailerErr := grpc.SetTrailer(ctx, metadata.Pairs("retry-after", retryAfter)); trailerErr != nil {
+			h.logger.Error(trailerErr)
+		}
+	}
+
+	return status.Error(codes.ResourceExhausted, err.Error())
+}
@@ -22,10 +22,14 @@ func (h *GRPCHandler) CreateData(ctx context.Context, in *pb.CreateDataRequest)
 		DataType: models.DataTypeEnum(in.Data.GetDataType().String()),
 		DataInfo: in.Data.GetDataInfo(),
 		Meta:     in.Data.GetMeta(),
+		FolderID: in.Data.GetFolderId(),
 	}
 
 	resultData, err := h.dataService.CreateData(ctx, &data)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &response, status.Error(codes.NotFound, "folder not found")
+		}
 		h.logger.Error(err)
 		return &response, status.Error(codes.Internal, err.Error())
 	}
@@ -36,15 +40,18 @@ func (h *GRPCHandler) CreateData(ctx context.Context, in *pb.CreateDataRequest)
 			DataType:   pb.DataTypeEnum(pb.DataTypeEnum_value[string(resultData.DataType)]),
 			DataInfo:   resultData.DataInfo,
 			Meta:       resultData.Meta,
+			FolderId:   resultData.FolderID,
 			UploadedAt: resultData.UploadedAt.Format(formatTimeLayout),
 		},
 	}, nil
 }
 
-func (h *GRPCHandler) GetDataList(ctx context.Context, in *pb.Empty) (*pb.GetDataResponse, error) {
+func (h *GRPCHandler) GetDataList(ctx context.Context, in *pb.GetDataListRequest) (*pb.GetDataResponse, error) {
 	response := pb.GetDataResponse{}
 
-	resultDataList, err := h.dataService.GetDataList(ctx)
+	filter := models.DataFilter{FolderID: in.FolderId, Recursive: in.GetRecursive(), Tags: in.GetTags()}
+
+	resultDataList, err := h.dataService.GetDataList(ctx, filter)
 	if err != nil {
 		h.logger.Error(err)
 		return &response, status.Error(codes.Internal, err.Error())
@@ -56,6 +63,8 @@ func (h *GRPCHandler) GetDataList(ctx context.Context, in *pb.Empty) (*pb.GetDat
 			DataType:   pb.DataTypeEnum(pb.DataTypeEnum_value[string(item.DataType)]),
 			DataInfo:   item.DataInfo,
 			Meta:       item.Meta,
+			FolderId:   item.FolderID,
+			Tags:       item.Tags,
 			UploadedAt: item.UploadedAt.Format(formatTimeLayout),
 		})
 	}
@@ -80,6 +89,8 @@ func (h *GRPCHandler) GetDataByID(ctx context.Context, in *pb.GetDataByIDRequest
 		DataType: pb.DataTypeEnum(pb.DataTypeEnum_value[string(resultData.DataType)]),
 		DataInfo: resultData.DataInfo,
 		Meta:     resultData.Meta,
+		FolderId: resultData.FolderID,
+		Tags:     resultData.Tags,
 	}
 
 	response.Data = &dataItem
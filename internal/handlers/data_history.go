@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *GRPCHandler) ListVersions(ctx context.Context, in *pb.ListVersionsRequest) (*pb.ListVersionsResponse, error) {
+	response := pb.ListVersionsResponse{}
+
+	versions, err := h.dataService.ListVersions(ctx, in.GetId())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &response, status.Error(codes.NotFound, err.Error())
+		}
+		h.logger.Error(err)
+		return &response, status.Error(codes.Internal, err.Error())
+	}
+
+	for _, item := range versions {
+		response.Versions = append(response.Versions, dataVersionToProto(item))
+	}
+
+	return &response, nil
+}
+
+func (h *GRPCHandler) GetVersion(ctx context.Context, in *pb.GetVersionRequest) (*pb.GetVersionResponse, error) {
+	response := pb.GetVersionResponse{}
+
+	version, err := h.dataService.GetVersion(ctx, in.GetId(), in.GetVersion())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &response, status.Error(codes.NotFound, "version not found")
+		}
+		h.logger.Error(err)
+		return &response, status.Error(codes.Internal, err.Error())
+	}
+
+	response.Data = &pb.DataItem{
+		Id:         version.Data.ID,
+		DataType:   pb.DataTypeEnum(pb.DataTypeEnum_value[string(version.Data.DataType)]),
+		DataInfo:   version.Data.DataInfo,
+		Meta:       version.Data.Meta,
+		UploadedAt: version.Data.UploadedAt.Format(formatTimeLayout),
+	}
+	response.Version = dataVersionToProto(version)
+
+	return &response, nil
+}
+
+func (h *GRPCHandler) RestoreVersion(ctx context.Context, in *pb.RestoreVersionRequest) (*pb.Empty, error) {
+	response := pb.Empty{}
+
+	err := h.dataService.RestoreVersion(ctx, in.GetId(), in.GetVersion())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &response, status.Error(codes.NotFound, "version not found")
+		}
+		h.logger.Error(err)
+		return &response, status.Error(codes.Internal, err.Error())
+	}
+
+	return &response, nil
+}
+
+func dataVersionToProto(version models.DataVersion) *pb.DataVersion {
+	return &pb.DataVersion{
+		Version:    version.Version,
+		UploadedAt: version.Data.UploadedAt.Format(formatTimeLayout),
+		ArchivedAt: version.ArchivedAt.Format(formatTimeLayout),
+	}
+}
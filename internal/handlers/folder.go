@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	serverServices "github.com/bbquite/go-pass-keeper/internal/service/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *GRPCHandler) CreateFolder(ctx context.Context, in *pb.CreateFolderRequest) (*pb.CreateFolderResponse, error) {
+	response := pb.CreateFolderResponse{}
+
+	folder, err := h.dataService.CreateFolder(ctx, in.GetName(), in.GetParentId())
+	if err != nil {
+		return &response, h.folderError(err)
+	}
+
+	response.Folder = &pb.Folder{
+		Id:        folder.ID,
+		ParentId:  folder.ParentID,
+		Name:      folder.Name,
+		CreatedAt: folder.CreatedAt.Format(formatTimeLayout),
+	}
+
+	return &response, nil
+}
+
+func (h *GRPCHandler) ListFolders(ctx context.Context, in *pb.Empty) (*pb.ListFoldersResponse, error) {
+	response := pb.ListFoldersResponse{}
+
+	folders, err := h.dataService.ListFolders(ctx)
+	if err != nil {
+		h.logger.Error(err)
+		return &response, status.Error(codes.Internal, err.Error())
+	}
+
+	for _, folder := range folders {
+		response.Folders = append(response.Folders, &pb.Folder{
+			Id:        folder.ID,
+			ParentId:  folder.ParentID,
+			Name:      folder.Name,
+			CreatedAt: folder.CreatedAt.Format(formatTimeLayout),
+		})
+	}
+
+	return &response, nil
+}
+
+func (h *GRPCHandler) RenameFolder(ctx context.Context, in *pb.RenameFolderRequest) (*pb.Empty, error) {
+	err := h.dataService.RenameFolder(ctx, in.GetId(), in.GetName())
+	if err != nil {
+		return &pb.Empty{}, h.folderError(err)
+	}
+	return &pb.Empty{}, nil
+}
+
+func (h *GRPCHandler) MoveFolder(ctx context.Context, in *pb.MoveFolderRequest) (*pb.Empty, error) {
+	err := h.dataService.MoveFolder(ctx, in.GetId(), in.GetParentId())
+	if err != nil {
+		return &pb.Empty{}, h.folderError(err)
+	}
+	return &pb.Empty{}, nil
+}
+
+func (h *GRPCHandler) DeleteFolder(ctx context.Context, in *pb.DeleteFolderRequest) (*pb.Empty, error) {
+	err := h.dataService.DeleteFolder(ctx, in.GetId())
+	if err != nil {
+		return &pb.Empty{}, h.folderError(err)
+	}
+	return &pb.Empty{}, nil
+}
+
+func (h *GRPCHandler) MoveData(ctx context.Context, in *pb.MoveDataRequest) (*pb.Empty, error) {
+	err := h.dataService.MoveData(ctx, in.GetId(), in.GetFolderId())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &pb.Empty{}, status.Error(codes.NotFound, "record or folder not found")
+		}
+		h.logger.Error(err)
+		return &pb.Empty{}, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.Empty{}, nil
+}
+
+func (h *GRPCHandler) folderError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, "folder not found")
+	case errors.Is(err, serverServices.ErrInvalidFolderName), errors.Is(err, serverServices.ErrFolderCycle):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	h.logger.Error(err)
+	return status.Error(codes.Internal, err.Error())
+}
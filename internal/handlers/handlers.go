@@ -11,19 +11,28 @@ import (
 
 type GRPCHandler struct {
 	pb.UnimplementedPassKeeperServiceServer
-	dataService *serverServices.DataService
-	authService *serverServices.AuthService
-	logger      *zap.SugaredLogger
+	dataService        *serverServices.DataService
+	authService        *serverServices.AuthService
+	accessTokenService *serverServices.AccessTokenService
+	auditService       *serverServices.AuditService
+	vaultService       *serverServices.VaultService
+	keyRotationService *serverServices.KeyRotationService
+	logger             *zap.SugaredLogger
 }
 
-func NewGRPCHandler(jwtManager *jwttoken.JWTManager, encryptorManager *encryptor.Encryptor, dbStorage *postgres.DBStorage, logger *zap.SugaredLogger) *GRPCHandler {
+func NewGRPCHandler(jwtManager *jwttoken.JWTManager, encryptorManager *encryptor.Encryptor, passwordHasher serverServices.PasswordHasher, passwordPolicy *serverServices.PasswordPolicy, revocationService *serverServices.RevocationService, loginLimiter *serverServices.LoginLimiter, accessTokenService *serverServices.AccessTokenService, auditService *serverServices.AuditService, dataKeyService *serverServices.DataKeyService, keyRotationService *serverServices.KeyRotationService, dataVersions int, dbStorage *postgres.DBStorage, logger *zap.SugaredLogger) *GRPCHandler {
 
-	dataService := serverServices.NewDataService(dbStorage, encryptorManager, logger)
-	authService := serverServices.NewAuthService(dbStorage, jwtManager, logger)
+	dataService := serverServices.NewDataService(dbStorage, dataKeyService, auditService, dataVersions, logger)
+	authService := serverServices.NewAuthService(dbStorage, jwtManager, passwordHasher, passwordPolicy, revocationService, encryptorManager, loginLimiter, dataKeyService, auditService, logger)
+	vaultService := serverServices.NewVaultService(dbStorage, auditService, logger)
 
 	return &GRPCHandler{
-		dataService: dataService,
-		authService: authService,
-		logger:      logger,
+		dataService:        dataService,
+		authService:        authService,
+		accessTokenService: accessTokenService,
+		auditService:       auditService,
+		vaultService:       vaultService,
+		keyRotationService: keyRotationService,
+		logger:             logger,
 	}
 }
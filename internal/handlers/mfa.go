@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	serverServices "github.com/bbquite/go-pass-keeper/internal/service/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *GRPCHandler) CompleteMfaLogin(ctx context.Context, in *pb.MfaLoginRequest) (*pb.UserAccountResponse, error) {
+	token, err := h.authService.CompleteMfaLogin(ctx, in.GetMfaToken(), in.GetCode(), in.GetDeviceName())
+
+	if err != nil {
+		if errors.Is(err, serverServices.ErrInvalidMfaToken) || errors.Is(err, serverServices.ErrIncorrectMfaCode) {
+			h.logger.Info(err)
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if errors.Is(err, serverServices.ErrLoginLocked) {
+			return nil, h.loginLockedError(ctx, err)
+		}
+
+		h.logger.Error(err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.UserAccountResponse{
+		Token:        token.Token,
+		RefreshToken: token.RefreshToken,
+	}, nil
+}
+
+func (h *GRPCHandler) EnrollTotp(ctx context.Context, in *pb.Empty) (*pb.EnrollTotpResponse, error) {
+	enrollment, err := h.authService.EnrollTotp(ctx)
+	if err != nil {
+		return nil, h.mfaError(err)
+	}
+
+	return &pb.EnrollTotpResponse{
+		Secret: enrollment.Secret,
+		Uri:    enrollment.URI,
+		QrCode: enrollment.QRCode,
+	}, nil
+}
+
+func (h *GRPCHandler) ConfirmTotp(ctx context.Context, in *pb.ConfirmTotpRequest) (*pb.ConfirmTotpResponse, error) {
+	recoveryCodes, err := h.authService.ConfirmTotp(ctx, in.GetCode())
+	if err != nil {
+		return nil, h.mfaError(err)
+	}
+
+	return &pb.ConfirmTotpResponse{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (h *GRPCHandler) DisableTotp(ctx context.Context, in *pb.DisableTotpRequest) (*pb.Empty, error) {
+	err := h.authService.DisableTotp(ctx, in.GetPassword(), in.GetCode())
+	if err != nil {
+		if errors.Is(err, serverServices.ErrLoginLocked) {
+			return nil, h.loginLockedError(ctx, err)
+		}
+		return nil, h.mfaError(err)
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (h *GRPCHandler) mfaError(err error) error {
+	switch {
+	case errors.Is(err, serverServices.ErrMfaAlreadyEnabled), errors.Is(err, serverServices.ErrMfaNotEnrolled):
+		h.logger.Info(err)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, serverServices.ErrIncorrectMfaCode), errors.Is(err, serverServices.ErrIncorrectLoginData):
+		h.logger.Info(err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	h.logger.Error(err)
+	return status.Error(codes.Internal, err.Error())
+}
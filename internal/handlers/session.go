@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	serverServices "github.com/bbquite/go-pass-keeper/internal/service/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *GRPCHandler) ListSessions(ctx context.Context, in *pb.Empty) (*pb.ListSessionsResponse, error) {
+	sessions, err := h.authService.ListSessions(ctx)
+	if err != nil {
+		h.logger.Error(err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var result []*pb.Session
+	for _, item := range sessions {
+		result = append(result, &pb.Session{
+			Id:          item.ID,
+			DeviceName:  item.DeviceName,
+			PeerAddress: item.PeerAddress,
+			UserAgent:   item.UserAgent,
+			CreatedAt:   item.CreatedAt.Format(formatTimeLayout),
+			LastSeenAt:  item.LastSeenAt.Format(formatTimeLayout),
+			Current:     item.Current,
+		})
+	}
+
+	return &pb.ListSessionsResponse{
+		Sessions: result,
+	}, nil
+}
+
+func (h *GRPCHandler) RevokeSession(ctx context.Context, in *pb.RevokeSessionRequest) (*pb.Empty, error) {
+	err := h.authService.RevokeSession(ctx, in.GetId())
+	if err != nil {
+		if errors.Is(err, serverServices.ErrSessionNotFound) {
+			h.logger.Info(err)
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
+		h.logger.Error(err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.Empty{}, nil
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	serverServices "github.com/bbquite/go-pass-keeper/internal/service/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *GRPCHandler) AddTags(ctx context.Context, in *pb.AddTagsRequest) (*pb.TagsResponse, error) {
+	tags, err := h.dataService.AddTags(ctx, in.GetId(), in.GetTags())
+	if err != nil {
+		return &pb.TagsResponse{}, h.tagsError(err)
+	}
+	return &pb.TagsResponse{Tags: tags}, nil
+}
+
+func (h *GRPCHandler) RemoveTags(ctx context.Context, in *pb.RemoveTagsRequest) (*pb.TagsResponse, error) {
+	tags, err := h.dataService.RemoveTags(ctx, in.GetId(), in.GetTags())
+	if err != nil {
+		return &pb.TagsResponse{}, h.tagsError(err)
+	}
+	return &pb.TagsResponse{Tags: tags}, nil
+}
+
+func (h *GRPCHandler) tagsError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, serverServices.ErrInvalidTags):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	h.logger.Error(err)
+	return status.Error(codes.Internal, err.Error())
+}
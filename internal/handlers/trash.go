@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	serverServices "github.com/bbquite/go-pass-keeper/internal/service/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *GRPCHandler) ListTrash(ctx context.Context, in *pb.Empty) (*pb.ListTrashResponse, error) {
+	response := pb.ListTrashResponse{}
+
+	items, err := h.dataService.ListTrash(ctx)
+	if err != nil {
+		h.logger.Error(err)
+		return &response, status.Error(codes.Internal, err.Error())
+	}
+
+	for _, item := range items {
+		response.Items = append(response.Items, &pb.TrashItem{
+			Data: &pb.DataItem{
+				Id:         item.Data.ID,
+				DataType:   pb.DataTypeEnum(pb.DataTypeEnum_value[string(item.Data.DataType)]),
+				DataInfo:   item.Data.DataInfo,
+				Meta:       item.Data.Meta,
+				FolderId:   item.Data.FolderID,
+				Tags:       item.Data.Tags,
+				UploadedAt: item.Data.UploadedAt.Format(formatTimeLayout),
+			},
+			DeletedAt: item.DeletedAt.Format(formatTimeLayout),
+		})
+	}
+
+	return &response, nil
+}
+
+func (h *GRPCHandler) RestoreData(ctx context.Context, in *pb.RestoreDataRequest) (*pb.Empty, error) {
+	response := pb.Empty{}
+
+	err := h.dataService.RestoreData(ctx, in.GetId())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &response, status.Error(codes.NotFound, "record not in trash")
+		}
+		h.logger.Error(err)
+		return &response, status.Error(codes.Internal, err.Error())
+	}
+
+	return &response, nil
+}
+
+func (h *GRPCHandler) PurgeData(ctx context.Context, in *pb.PurgeDataRequest) (*pb.Empty, error) {
+	response := pb.Empty{}
+
+	err := h.dataService.PurgeData(ctx, in.GetId())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &response, status.Error(codes.NotFound, "record not in trash")
+		}
+		h.logger.Error(err)
+		return &response, status.Error(codes.Internal, err.Error())
+	}
+
+	return &response, nil
+}
+
+func (h *GRPCHandler) SealTrashedData(ctx context.Context, in *pb.SealTrashedDataRequest) (*pb.Empty, error) {
+	response := pb.Empty{}
+
+	err := h.dataService.SealTrashedData(ctx, &models.DataStoreFormat{
+		ID:       in.GetData().GetId(),
+		DataType: models.DataTypeEnum(in.GetData().GetDataType().String()),
+		DataInfo: in.GetData().GetDataInfo(),
+		Meta:     in.GetData().GetMeta(),
+		Tags:     in.GetData().GetTags(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &response, status.Error(codes.NotFound, "record not in trash")
+		}
+		if errors.Is(err, serverServices.ErrNotSealed) || errors.Is(err, serverServices.ErrInvalidTags) {
+			h.logger.Info(err)
+			return &response, status.Error(codes.InvalidArgument, err.Error())
+		}
+		h.logger.Error(err)
+		return &response, status.Error(codes.Internal, err.Error())
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	encryptor "github.com/bbquite/go-pass-keeper/internal/encryption"
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	serverServices "github.com/bbquite/go-pass-keeper/internal/service/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *GRPCHandler) GetVaultParams(ctx context.Context, in *pb.Empty) (*pb.GetVaultParamsResponse, error) {
+	params, err := h.vaultService.GetVaultParams(ctx)
+	if err != nil {
+		h.logger.Error(err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if !params.Enabled() {
+		return &pb.GetVaultParamsResponse{}, nil
+	}
+
+	return &pb.GetVaultParamsResponse{
+		Enabled: true,
+		Params: &pb.VaultParams{
+			Salt:        params.Salt,
+			Memory:      params.Memory,
+			Iterations:  params.Iterations,
+			Parallelism: uint32(params.Parallelism),
+			Verifier:    params.Verifier,
+		},
+	}, nil
+}
+
+func (h *GRPCHandler) SetVaultParams(ctx context.Context, in *pb.VaultParams) (*pb.Empty, error) {
+	if in.GetParallelism() > math.MaxUint8 {
+		return nil, status.Error(codes.InvalidArgument, encryptor.ErrInvalidVaultParams.Error())
+	}
+
+	err := h.vaultService.SetVaultParams(ctx, models.VaultParams{
+		Salt:        in.GetSalt(),
+		Memory:      in.GetMemory(),
+		Iterations:  in.GetIterations(),
+		Parallelism: uint8(in.GetParallelism()),
+		Verifier:    in.GetVerifier(),
+	})
+	if err != nil {
+		if errors.Is(err, encryptor.ErrInvalidVaultParams) {
+			h.logger.Info(err)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, serverServices.ErrVaultAlreadyEnabled) {
+			h.logger.Info(err)
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		h.logger.Error(err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.Empty{}, nil
+}
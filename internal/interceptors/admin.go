@@ -0,0 +1,74 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/subtle"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AdminInterceptor guards admin methods with a static token passed in the
+// "x-admin-token" metadata. Admin methods are rejected when no token is configured.
+type AdminInterceptor struct {
+	adminToken   string
+	adminMethods []string
+}
+
+func NewAdminInterceptor(adminToken string, adminMethods []string) *AdminInterceptor {
+	return &AdminInterceptor{
+		adminToken:   adminToken,
+		adminMethods: adminMethods,
+	}
+}
+
+func (ai *AdminInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !slices.Contains(ai.adminMethods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		err := ai.authorize(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (ai *AdminInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !slices.Contains(ai.adminMethods, info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		err := ai.authorize(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func (ai *AdminInterceptor) authorize(ctx context.Context) error {
+	if ai.adminToken == "" {
+		return status.Error(codes.PermissionDenied, "admin API disabled")
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "Metadata not provided")
+	}
+
+	values := md["x-admin-token"]
+	if len(values) == 0 || subtle.ConstantTimeCompare([]byte(values[0]), []byte(ai.adminToken)) != 1 {
+		return status.Error(codes.PermissionDenied, "Invalid admin token")
+	}
+
+	return nil
+}
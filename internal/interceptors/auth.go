@@ -2,78 +2,230 @@ package interceptors
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"slices"
 	"strings"
 
+	"github.com/bbquite/go-pass-keeper/internal/models"
 	"github.com/bbquite/go-pass-keeper/internal/utils"
 	jwttoken "github.com/bbquite/go-pass-keeper/pkg/jwt_token"
 	"github.com/golang-jwt/jwt/v4"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
+type tokenRevocationChecker interface {
+	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
+	IsTokenGenerationValid(ctx context.Context, accountID uint32, generation uint32) (bool, error)
+	IsSessionActive(ctx context.Context, sessionID uint32) (bool, error)
+}
+
+type certificateAuthenticator interface {
+	AuthenticateCertificate(ctx context.Context, cert *x509.Certificate) (uint32, error)
+}
+
 type AuthInterceptor struct {
 	jwtManager    *jwttoken.JWTManager
+	revocation    tokenRevocationChecker
+	accessTokens  accessTokenAuthenticator
+	certAuth      certificateAuthenticator
 	noAuthMethods []string
 }
 
-func NewAuthInterceptor(jwtManager *jwttoken.JWTManager, noAuthMethods []string) *AuthInterceptor {
+func NewAuthInterceptor(jwtManager *jwttoken.JWTManager, revocation tokenRevocationChecker, accessTokens accessTokenAuthenticator, noAuthMethods []string) *AuthInterceptor {
 	return &AuthInterceptor{
 		jwtManager:    jwtManager,
+		revocation:    revocation,
+		accessTokens:  accessTokens,
 		noAuthMethods: noAuthMethods,
 	}
 }
 
+// EnableCertificateAuth lets requests without a bearer token authenticate with
+// a client certificate verified during the TLS handshake.
+func (ai *AuthInterceptor) EnableCertificateAuth(certAuth certificateAuthenticator) {
+	ai.certAuth = certAuth
+}
+
 func (ai *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		if slices.Contains(ai.noAuthMethods, info.FullMethod) {
 			return handler(ctx, req)
 		}
 
-		userID, err := ai.authorize(ctx)
+		ctx, accessToken, err := ai.authenticate(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		ctx = context.WithValue(ctx, utils.AccountIDKey, userID)
+		if accessToken != nil {
+			return ai.handleScoped(ctx, accessToken, req, info, handler)
+		}
 
 		return handler(ctx, req)
 	}
 }
 
-func (ai *AuthInterceptor) authorize(ctx context.Context) (uint32, error) {
+// Stream authenticates streaming RPCs of any direction. The claims are
+// injected through a wrapped stream, since a stream's context cannot be
+// replaced directly.
+func (ai *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if slices.Contains(ai.noAuthMethods, info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, accessToken, err := ai.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		// No streaming RPC is covered by access token scopes.
+		if accessToken != nil {
+			return status.Error(codes.PermissionDenied, "method not allowed for access tokens")
+		}
+
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate returns ctx carrying the caller identity. A bearer token always
+// takes precedence; the client certificate is used only when no token is sent.
+// The returned access token is set only for personal access tokens, whose
+// scopes the caller has to enforce.
+func (ai *AuthInterceptor) authenticate(ctx context.Context) (context.Context, *models.AccessToken, error) {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		if cert := verifiedPeerCertificate(ctx); ai.certAuth != nil && cert != nil {
+			accountID, err := ai.certAuth.AuthenticateCertificate(ctx, cert)
+			if err != nil {
+				return nil, nil, status.Error(codes.Unauthenticated, err.Error())
+			}
+			return context.WithValue(ctx, utils.AccountIDKey, accountID), nil, nil
+		}
+		return nil, nil, status.Error(codes.Unauthenticated, "Invalid access token")
+	}
+
+	if strings.HasPrefix(token, models.AccessTokenPrefix) {
+		accessToken, err := ai.accessTokens.AuthenticateAccessToken(ctx, token)
+		if err != nil {
+			return nil, nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		ctx = context.WithValue(ctx, utils.AccountIDKey, accessToken.AccountID)
+		ctx = context.WithValue(ctx, utils.AccessTokenIDKey, accessToken.ID)
+		return ctx, &accessToken, nil
+	}
+
+	claims, err := ai.authorize(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return contextWithClaims(ctx, claims), nil, nil
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return 0, status.Error(codes.Unauthenticated, "Metadata not provided")
+		return "", false
 	}
 
 	values := md["authorization"]
 	if len(values) == 0 {
-		return 0, status.Error(codes.Unauthenticated, "Invalid access token")
+		return "", false
+	}
+
+	return strings.TrimPrefix(values[0], "Bearer "), true
+}
+
+// verifiedPeerCertificate returns the client leaf certificate when the TLS
+// handshake verified it against the client CA.
+func verifiedPeerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
 	}
 
-	accessToken := values[0]
-	accessToken = strings.TrimPrefix(accessToken, "Bearer ")
+	return tlsInfo.State.VerifiedChains[0][0]
+}
+
+func contextWithClaims(ctx context.Context, claims *jwttoken.Claims) context.Context {
+	ctx = context.WithValue(ctx, utils.AccountIDKey, claims.UserID)
+	ctx = context.WithValue(ctx, utils.TokenIDKey, claims.ID)
+	ctx = context.WithValue(ctx, utils.TokenExpiryKey, claims.ExpiresAt.Time)
+	ctx = context.WithValue(ctx, utils.SessionIDKey, claims.SessionID)
+	return ctx
+}
+
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
 
+func (ai *AuthInterceptor) authorize(ctx context.Context, accessToken string) (*jwttoken.Claims, error) {
 	authorized, err := ai.jwtManager.IsAuthorized(accessToken)
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
-			return 0, status.Error(codes.Unauthenticated, "TokenExpired")
+			return nil, status.Error(codes.Unauthenticated, "TokenExpired")
 		}
-		return 0, status.Error(codes.Internal, err.Error())
+		// Unknown kid, bad signature or foreign issuer/audience: the client
+		// should obtain a new token rather than treat it as a server failure.
+		return nil, status.Error(codes.Unauthenticated, "Invalid access token")
 	}
 
 	if authorized {
-		userID, err := ai.jwtManager.ExtractIDFromToken(accessToken)
+		claims, err := ai.jwtManager.ExtractClaimsFromToken(accessToken)
+		if err != nil || claims.ID == "" || claims.ExpiresAt == nil || claims.MfaPending {
+			return nil, status.Error(codes.Unauthenticated, "Invalid access token")
+		}
+
+		revoked, err := ai.revocation.IsTokenRevoked(ctx, claims.ID)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if revoked {
+			return nil, status.Error(codes.Unauthenticated, "TokenRevoked")
+		}
+
+		current, err := ai.revocation.IsTokenGenerationValid(ctx, claims.UserID, claims.Generation)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if !current {
+			return nil, status.Error(codes.Unauthenticated, "TokenRevoked")
+		}
+
+		// Tokens issued before device sessions existed carry no session ID;
+		// rejecting them makes the client refresh into a session.
+		if claims.SessionID == 0 {
+			return nil, status.Error(codes.Unauthenticated, "TokenRevoked")
+		}
+
+		active, err := ai.revocation.IsSessionActive(ctx, claims.SessionID)
 		if err != nil {
-			return 0, status.Error(codes.Unauthenticated, "Invalid access token")
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if !active {
+			return nil, status.Error(codes.Unauthenticated, "SessionRevoked")
 		}
-		return userID, nil
+
+		return claims, nil
 	}
 
-	return 0, status.Error(codes.Unauthenticated, "TokenExpired")
+	return nil, status.Error(codes.Unauthenticated, "TokenExpired")
 }
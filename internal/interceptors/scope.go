@@ -0,0 +1,146 @@
+package interceptors
+
+import (
+	"context"
+	"slices"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type accessTokenAuthenticator interface {
+	AuthenticateAccessToken(ctx context.Context, token string) (models.AccessToken, error)
+	GetRecordDataType(ctx context.Context, accountID uint32, recordID uint32) (models.DataTypeEnum, bool, error)
+}
+
+// accessTokenMethods lists the RPCs a personal access token may call and
+// whether each of them writes. Only data methods are listed, so a token can
+// never manage the account, its sessions or other tokens.
+var accessTokenMethods = map[string]bool{
+	pb.PassKeeperService_GetDataList_FullMethodName: false,
+	pb.PassKeeperService_GetDataByID_FullMethodName: false,
+	pb.PassKeeperService_CreateData_FullMethodName:  true,
+	pb.PassKeeperService_UpdateData_FullMethodName:  true,
+	pb.PassKeeperService_DeleteData_FullMethodName:  true,
+	pb.PassKeeperService_MoveData_FullMethodName:    true,
+	pb.PassKeeperService_AddTags_FullMethodName:     true,
+	pb.PassKeeperService_RemoveTags_FullMethodName:  true,
+
+	pb.PassKeeperService_ListVersions_FullMethodName:   false,
+	pb.PassKeeperService_GetVersion_FullMethodName:     false,
+	pb.PassKeeperService_RestoreVersion_FullMethodName: true,
+}
+
+// handleScoped enforces the access token scopes before the handler runs and
+// filters list responses down to the records the token may see.
+func (ai *AuthInterceptor) handleScoped(ctx context.Context, accessToken *models.AccessToken, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	scopes := accessToken.Scopes
+
+	writes, ok := accessTokenMethods[info.FullMethod]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "method not allowed for access tokens")
+	}
+	if writes && scopes.ReadOnly {
+		return nil, status.Error(codes.PermissionDenied, "access token is read-only")
+	}
+
+	var err error
+	switch in := req.(type) {
+	case *pb.CreateDataRequest:
+		// A token limited to records can only touch records that already exist.
+		if len(scopes.RecordIDs) > 0 {
+			return nil, status.Error(codes.PermissionDenied, "access token is limited to existing records")
+		}
+		err = checkDataTypeScope(scopes, pbDataType(in.GetData().GetDataType()))
+	case *pb.UpdateDataRequest:
+		err = checkRecordScope(scopes, in.GetData().GetId())
+		if err == nil {
+			err = checkDataTypeScope(scopes, pbDataType(in.GetData().GetDataType()))
+		}
+	case *pb.GetDataByIDRequest:
+		err = ai.checkStoredRecordScope(ctx, accessToken, in.GetId())
+	case *pb.DeleteDataRequest:
+		err = ai.checkStoredRecordScope(ctx, accessToken, in.GetId())
+	case *pb.MoveDataRequest:
+		err = ai.checkStoredRecordScope(ctx, accessToken, in.GetId())
+	case *pb.AddTagsRequest:
+		err = ai.checkStoredRecordScope(ctx, accessToken, in.GetId())
+	case *pb.RemoveTagsRequest:
+		err = ai.checkStoredRecordScope(ctx, accessToken, in.GetId())
+	case *pb.ListVersionsRequest:
+		err = ai.checkStoredRecordScope(ctx, accessToken, in.GetId())
+	case *pb.GetVersionRequest:
+		err = ai.checkStoredRecordScope(ctx, accessToken, in.GetId())
+	case *pb.RestoreVersionRequest:
+		err = ai.checkStoredRecordScope(ctx, accessToken, in.GetId())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	if list, ok := resp.(*pb.GetDataResponse); ok {
+		var allowed []*pb.DataItem
+		for _, item := range list.GetDataList() {
+			if allowsRecord(scopes, item.GetId()) && allowsDataType(scopes, pbDataType(item.GetDataType())) {
+				allowed = append(allowed, item)
+			}
+		}
+		list.DataList = allowed
+	}
+
+	return resp, nil
+}
+
+// checkStoredRecordScope checks a request carrying only a record ID, looking
+// up the record type when the token is limited to some data types. Missing
+// records are left to the handler to report.
+func (ai *AuthInterceptor) checkStoredRecordScope(ctx context.Context, accessToken *models.AccessToken, recordID uint32) error {
+	err := checkRecordScope(accessToken.Scopes, recordID)
+	if err != nil || len(accessToken.Scopes.DataTypes) == 0 {
+		return err
+	}
+
+	dataType, found, err := ai.accessTokens.GetRecordDataType(ctx, accessToken.AccountID, recordID)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		return nil
+	}
+
+	return checkDataTypeScope(accessToken.Scopes, dataType)
+}
+
+func checkRecordScope(scopes models.AccessTokenScopes, recordID uint32) error {
+	if !allowsRecord(scopes, recordID) {
+		return status.Error(codes.PermissionDenied, "record not allowed for access token")
+	}
+	return nil
+}
+
+func checkDataTypeScope(scopes models.AccessTokenScopes, dataType models.DataTypeEnum) error {
+	if !allowsDataType(scopes, dataType) {
+		return status.Error(codes.PermissionDenied, "data type not allowed for access token")
+	}
+	return nil
+}
+
+func allowsRecord(scopes models.AccessTokenScopes, recordID uint32) bool {
+	return len(scopes.RecordIDs) == 0 || slices.Contains(scopes.RecordIDs, recordID)
+}
+
+func allowsDataType(scopes models.AccessTokenScopes, dataType models.DataTypeEnum) bool {
+	return len(scopes.DataTypes) == 0 || slices.Contains(scopes.DataTypes, dataType)
+}
+
+func pbDataType(dataType pb.DataTypeEnum) models.DataTypeEnum {
+	return models.DataTypeEnum(dataType.String())
+}
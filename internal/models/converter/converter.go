@@ -11,6 +11,7 @@ func DataStoreFormatToProtoFormat(data *models.DataStoreFormat) *pb.DataItem {
 		DataType: pb.DataTypeEnum(pb.DataTypeEnum_value[string(data.DataType)]),
 		DataInfo: data.DataInfo,
 		Meta:     data.Meta,
+		FolderId: data.FolderID,
 	}
 	return dataConverted
 }
@@ -17,47 +17,287 @@ type DataStoreFormat struct {
 	DataType   DataTypeEnum `json:"data_type"`
 	DataInfo   string       `json:"data_info"`
 	Meta       string       `json:"meta"`
+	FolderID   uint32       `json:"folder_id,omitempty"`
+	Tags       []string     `json:"tags,omitempty"`
 	UploadedAt time.Time    `json:"uploaded_at"`
 }
 
+// DataFilter selects records of an account. A nil FolderID selects records
+// of every folder and zero the records outside any folder. Recursive also
+// selects the records of the subfolders. Tags selects records having all of
+// the tags.
+type DataFilter struct {
+	FolderID  *uint32
+	Recursive bool
+	Tags      []string
+}
+
+// Folder groups records of an account. Zero ParentID is the top level.
+type Folder struct {
+	ID        uint32    `json:"id"`
+	ParentID  uint32    `json:"parent_id,omitempty"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DataVersion is a previous version of a record. UploadedAt is when the
+// version was written and ArchivedAt when it was replaced.
+type DataVersion struct {
+	Version    uint32          `json:"version"`
+	Data       DataStoreFormat `json:"data"`
+	ArchivedAt time.Time       `json:"archived_at"`
+}
+
+// TrashItem is a deleted record kept until it is restored or purged.
+type TrashItem struct {
+	Data      DataStoreFormat `json:"data"`
+	DeletedAt time.Time       `json:"deleted_at"`
+}
+
 type UserAccountData struct {
-	Username string `json:"login"`
-	Password string `json:"password"`
+	Username   string `json:"login"`
+	Password   string `json:"password"`
+	DeviceName string `json:"device_name"`
 }
 
 type Account struct {
-	ID        uint32    `json:"id"`
-	Username  string    `json:"login"`
-	Password  string    `json:"password"`
-	CreatedOn time.Time `json:"created_on"`
+	ID              uint32    `json:"id"`
+	Username        string    `json:"login"`
+	Password        string    `json:"password"`
+	TOTPEnabled     bool      `json:"totp_enabled"`
+	TokenGeneration uint32    `json:"token_generation"`
+	CreatedOn       time.Time `json:"created_on"`
+}
+
+type AccountTOTP struct {
+	AccountID uint32 `json:"account_id"`
+	Secret    string `json:"secret"`
+	Enabled   bool   `json:"enabled"`
+	LastStep  int64  `json:"last_step"`
+}
+
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+	QRCode string `json:"qr_code"`
+}
+
+// VaultParams describes how the client derives the vault key from the master
+// password: Argon2id with the given salt and cost. Verifier is a known value
+// sealed with the vault key, so a wrong master password is detected. The
+// server stores these values but never sees the key.
+type VaultParams struct {
+	Salt        string `json:"salt"`
+	Memory      uint32 `json:"memory"`
+	Iterations  uint32 `json:"iterations"`
+	Parallelism uint8  `json:"parallelism"`
+	Verifier    string `json:"verifier"`
+}
+
+// Enabled reports whether end-to-end encryption is set up for the account.
+func (p VaultParams) Enabled() bool {
+	return p.Salt != ""
+}
+
+// AccountDataKey is the data encryption key of an account wrapped by the
+// server master key. Legacy keys belong to accounts created before data keys
+// existed, whose old records may still be encrypted with the master key.
+type AccountDataKey struct {
+	AccountID  uint32 `json:"account_id"`
+	WrappedKey string `json:"-"`
+	Legacy     bool   `json:"legacy"`
+}
+
+// StoredRecord is a record with the account it belongs to.
+type StoredRecord struct {
+	AccountID uint32
+	Record    DataStoreFormat
+}
+
+// Key rotation stages, in the order they run.
+const (
+	KeyRotationDataKeys    = "data_keys"
+	KeyRotationTOTPSecrets = "totp_secrets"
+	KeyRotationRecords     = "records"
+	KeyRotationHistory     = "history"
+	KeyRotationDone        = "done"
+)
+
+// KeyRotation is the persisted progress of re-encrypting stored data with a
+// master key. Cursor is the last processed row ID of the current stage.
+// Processed and Total count rows of the current stage; Total is not stored.
+// JobVersion is the version of the job that produced the progress.
+type KeyRotation struct {
+	KeyID      string     `json:"key_id"`
+	JobVersion int        `json:"job_version"`
+	Stage      string     `json:"stage"`
+	Cursor     uint32     `json:"cursor"`
+	Processed  int64      `json:"processed"`
+	Total      int64      `json:"total"`
+	Updated    int64      `json:"updated"`
+	Failed     int64      `json:"failed"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+type RefreshToken struct {
+	ID        uint32     `json:"id"`
+	AccountID uint32     `json:"account_id"`
+	FamilyID  string     `json:"family_id"`
+	TokenHash string     `json:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedOn time.Time  `json:"created_on"`
+}
+
+type Session struct {
+	ID          uint32     `json:"id"`
+	AccountID   uint32     `json:"account_id"`
+	FamilyID    string     `json:"-"`
+	DeviceName  string     `json:"device_name"`
+	PeerAddress string     `json:"peer_address"`
+	UserAgent   string     `json:"user_agent"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastSeenAt  time.Time  `json:"last_seen_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	Current     bool       `json:"current"`
+}
+
+// AccessTokenPrefix marks personal access tokens, so they can be told apart
+// from JWTs in the authorization header.
+const AccessTokenPrefix = "gpk_"
+
+// AccessTokenScopes limits what a personal access token may do. Empty
+// DataTypes or RecordIDs mean no restriction on that dimension.
+type AccessTokenScopes struct {
+	ReadOnly  bool           `json:"read_only"`
+	DataTypes []DataTypeEnum `json:"data_types,omitempty"`
+	RecordIDs []uint32       `json:"record_ids,omitempty"`
+}
+
+type AccessToken struct {
+	ID         uint32            `json:"id"`
+	AccountID  uint32            `json:"account_id"`
+	Name       string            `json:"name"`
+	TokenHash  string            `json:"-"`
+	Scopes     AccessTokenScopes `json:"scopes"`
+	ExpiresAt  time.Time         `json:"expires_at"`
+	CreatedAt  time.Time         `json:"created_at"`
+	LastUsedAt *time.Time        `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time        `json:"revoked_at,omitempty"`
+}
+
+// Audit event types.
+const (
+	AuditRegister          = "register"
+	AuditLogin             = "login"
+	AuditLoginFailed       = "login_failed"
+	AuditLogout            = "logout"
+	AuditTokenRefresh      = "token_refresh"
+	AuditTokenReuse        = "token_reuse"
+	AuditPasswordChange    = "password_change"
+	AuditSessionRevoke     = "session_revoke"
+	AuditMfaEnable         = "mfa_enable"
+	AuditMfaDisable        = "mfa_disable"
+	AuditAccessTokenCreate = "access_token_create"
+	AuditAccessTokenRevoke = "access_token_revoke"
+	AuditDataCreate        = "data_create"
+	AuditDataRead          = "data_read"
+	AuditDataUpdate        = "data_update"
+	AuditDataDelete        = "data_delete"
+	AuditDataRestore       = "data_restore"
+	AuditDataPurge         = "data_purge"
+	AuditDataMove          = "data_move"
+	AuditDataTag           = "data_tag"
+	AuditAccountExport     = "account_export"
+	AuditVaultEnable       = "vault_enable"
+)
+
+// AuditEvent is an entry of the account audit log. Zero RecordID, SessionID
+// and AccessTokenID mean the event is not tied to one.
+type AuditEvent struct {
+	ID            uint64    `json:"id"`
+	AccountID     uint32    `json:"account_id"`
+	EventType     string    `json:"event_type"`
+	RecordID      uint32    `json:"record_id,omitempty"`
+	SessionID     uint32    `json:"session_id,omitempty"`
+	AccessTokenID uint32    `json:"access_token_id,omitempty"`
+	PeerAddress   string    `json:"peer_address"`
+	Details       string    `json:"details,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// AuditFilter selects audit events of an account, newest first. BeforeID is
+// the pagination cursor: only events with a smaller ID are returned.
+type AuditFilter struct {
+	From       *time.Time
+	To         *time.Time
+	EventTypes []string
+	BeforeID   uint64
+	Limit      int
+}
+
+// LoginAttempt is the attempt counter of a username or peer address. The
+// durations are measured on the database clock. LockedAfterLast is set when
+// the last lock was set after the last counted attempt, i.e. no attempt is
+// still being verified.
+type LoginAttempt struct {
+	Failures        int
+	SinceLast       time.Duration
+	LockedFor       time.Duration
+	LockedAfterLast bool
+}
+
+type AccountInfo struct {
+	ID          uint32    `json:"id"`
+	Username    string    `json:"login"`
+	TOTPEnabled bool      `json:"totp_enabled"`
+	CreatedOn   time.Time `json:"created_on"`
+}
+
+type TokenHistoryItem struct {
+	FamilyID  string     `json:"family_id"`
+	CreatedOn time.Time  `json:"created_on"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
 }
 
 type PairData struct {
-	ID   uint32 `json:"id,omitempty"`
-	Key  string `json:"key"`
-	Pwd  string `json:"pwd"`
-	Meta string `json:"meta,omitempty"`
+	ID       uint32   `json:"id,omitempty"`
+	Key      string   `json:"key"`
+	Pwd      string   `json:"pwd"`
+	Meta     string   `json:"meta,omitempty"`
+	FolderID uint32   `json:"folder_id,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
 }
 
 type TextData struct {
-	ID   uint32 `json:"id,omitempty"`
-	Text string `json:"text"`
-	Meta string `json:"meta,omitempty"`
+	ID       uint32   `json:"id,omitempty"`
+	Text     string   `json:"text"`
+	Meta     string   `json:"meta,omitempty"`
+	FolderID uint32   `json:"folder_id,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
 }
 
 type BinaryData struct {
-	ID       uint32 `json:"id,omitempty"`
-	FileName string `json:"file_name"`
-	FileSize int64  `json:"file_size"`
-	Binary   []byte `json:"binary"`
-	Meta     string `json:"meta,omitempty"`
+	ID       uint32   `json:"id,omitempty"`
+	FileName string   `json:"file_name"`
+	FileSize int64    `json:"file_size"`
+	Binary   []byte   `json:"binary"`
+	Meta     string   `json:"meta,omitempty"`
+	FolderID uint32   `json:"folder_id,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
 }
 
 type CardData struct {
-	ID        uint32 `json:"id,omitempty"`
-	CardNum   string `json:"card_num"`
-	CardCvv   string `json:"card_cvv"`
-	CardOwner string `json:"card_owner"`
-	CardExp   string `json:"card_exp"`
-	Meta      string `json:"meta,omitempty"`
+	ID        uint32   `json:"id,omitempty"`
+	CardNum   string   `json:"card_num"`
+	CardCvv   string   `json:"card_cvv"`
+	CardOwner string   `json:"card_owner"`
+	CardExp   string   `json:"card_exp"`
+	Meta      string   `json:"meta,omitempty"`
+	FolderID  uint32   `json:"folder_id,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
 }
@@ -19,13 +19,47 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PassKeeperService_AuthUser_FullMethodName     = "/internal.proto.PassKeeperService/AuthUser"
-	PassKeeperService_RegisterUser_FullMethodName = "/internal.proto.PassKeeperService/RegisterUser"
-	PassKeeperService_CreateData_FullMethodName   = "/internal.proto.PassKeeperService/CreateData"
-	PassKeeperService_GetDataList_FullMethodName  = "/internal.proto.PassKeeperService/GetDataList"
-	PassKeeperService_GetDataByID_FullMethodName  = "/internal.proto.PassKeeperService/GetDataByID"
-	PassKeeperService_UpdateData_FullMethodName   = "/internal.proto.PassKeeperService/UpdateData"
-	PassKeeperService_DeleteData_FullMethodName   = "/internal.proto.PassKeeperService/DeleteData"
+	PassKeeperService_AuthUser_FullMethodName           = "/internal.proto.PassKeeperService/AuthUser"
+	PassKeeperService_RegisterUser_FullMethodName       = "/internal.proto.PassKeeperService/RegisterUser"
+	PassKeeperService_RefreshToken_FullMethodName       = "/internal.proto.PassKeeperService/RefreshToken"
+	PassKeeperService_Logout_FullMethodName             = "/internal.proto.PassKeeperService/Logout"
+	PassKeeperService_CompleteMfaLogin_FullMethodName   = "/internal.proto.PassKeeperService/CompleteMfaLogin"
+	PassKeeperService_ChangePassword_FullMethodName     = "/internal.proto.PassKeeperService/ChangePassword"
+	PassKeeperService_DeleteAccount_FullMethodName      = "/internal.proto.PassKeeperService/DeleteAccount"
+	PassKeeperService_ExportAccount_FullMethodName      = "/internal.proto.PassKeeperService/ExportAccount"
+	PassKeeperService_ListSessions_FullMethodName       = "/internal.proto.PassKeeperService/ListSessions"
+	PassKeeperService_RevokeSession_FullMethodName      = "/internal.proto.PassKeeperService/RevokeSession"
+	PassKeeperService_CreateAccessToken_FullMethodName  = "/internal.proto.PassKeeperService/CreateAccessToken"
+	PassKeeperService_ListAccessTokens_FullMethodName   = "/internal.proto.PassKeeperService/ListAccessTokens"
+	PassKeeperService_RevokeAccessToken_FullMethodName  = "/internal.proto.PassKeeperService/RevokeAccessToken"
+	PassKeeperService_ListAuditEvents_FullMethodName    = "/internal.proto.PassKeeperService/ListAuditEvents"
+	PassKeeperService_GetVaultParams_FullMethodName     = "/internal.proto.PassKeeperService/GetVaultParams"
+	PassKeeperService_SetVaultParams_FullMethodName     = "/internal.proto.PassKeeperService/SetVaultParams"
+	PassKeeperService_EnrollTotp_FullMethodName         = "/internal.proto.PassKeeperService/EnrollTotp"
+	PassKeeperService_ConfirmTotp_FullMethodName        = "/internal.proto.PassKeeperService/ConfirmTotp"
+	PassKeeperService_DisableTotp_FullMethodName        = "/internal.proto.PassKeeperService/DisableTotp"
+	PassKeeperService_AdminUnlockLogin_FullMethodName   = "/internal.proto.PassKeeperService/AdminUnlockLogin"
+	PassKeeperService_AdminReencryptData_FullMethodName = "/internal.proto.PassKeeperService/AdminReencryptData"
+	PassKeeperService_CreateData_FullMethodName         = "/internal.proto.PassKeeperService/CreateData"
+	PassKeeperService_GetDataList_FullMethodName        = "/internal.proto.PassKeeperService/GetDataList"
+	PassKeeperService_GetDataByID_FullMethodName        = "/internal.proto.PassKeeperService/GetDataByID"
+	PassKeeperService_UpdateData_FullMethodName         = "/internal.proto.PassKeeperService/UpdateData"
+	PassKeeperService_DeleteData_FullMethodName         = "/internal.proto.PassKeeperService/DeleteData"
+	PassKeeperService_MoveData_FullMethodName           = "/internal.proto.PassKeeperService/MoveData"
+	PassKeeperService_AddTags_FullMethodName            = "/internal.proto.PassKeeperService/AddTags"
+	PassKeeperService_RemoveTags_FullMethodName         = "/internal.proto.PassKeeperService/RemoveTags"
+	PassKeeperService_CreateFolder_FullMethodName       = "/internal.proto.PassKeeperService/CreateFolder"
+	PassKeeperService_ListFolders_FullMethodName        = "/internal.proto.PassKeeperService/ListFolders"
+	PassKeeperService_RenameFolder_FullMethodName       = "/internal.proto.PassKeeperService/RenameFolder"
+	PassKeeperService_MoveFolder_FullMethodName         = "/internal.proto.PassKeeperService/MoveFolder"
+	PassKeeperService_DeleteFolder_FullMethodName       = "/internal.proto.PassKeeperService/DeleteFolder"
+	PassKeeperService_ListTrash_FullMethodName          = "/internal.proto.PassKeeperService/ListTrash"
+	PassKeeperService_RestoreData_FullMethodName        = "/internal.proto.PassKeeperService/RestoreData"
+	PassKeeperService_PurgeData_FullMethodName          = "/internal.proto.PassKeeperService/PurgeData"
+	PassKeeperService_SealTrashedData_FullMethodName    = "/internal.proto.PassKeeperService/SealTrashedData"
+	PassKeeperService_ListVersions_FullMethodName       = "/internal.proto.PassKeeperService/ListVersions"
+	PassKeeperService_GetVersion_FullMethodName         = "/internal.proto.PassKeeperService/GetVersion"
+	PassKeeperService_RestoreVersion_FullMethodName     = "/internal.proto.PassKeeperService/RestoreVersion"
 )
 
 // PassKeeperServiceClient is the client API for PassKeeperService service.
@@ -34,11 +68,45 @@ const (
 type PassKeeperServiceClient interface {
 	AuthUser(ctx context.Context, in *UserAccountRequest, opts ...grpc.CallOption) (*UserAccountResponse, error)
 	RegisterUser(ctx context.Context, in *UserAccountRequest, opts ...grpc.CallOption) (*UserAccountResponse, error)
+	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*UserAccountResponse, error)
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*Empty, error)
+	CompleteMfaLogin(ctx context.Context, in *MfaLoginRequest, opts ...grpc.CallOption) (*UserAccountResponse, error)
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*UserAccountResponse, error)
+	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*Empty, error)
+	ExportAccount(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error)
+	ListSessions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*Empty, error)
+	CreateAccessToken(ctx context.Context, in *CreateAccessTokenRequest, opts ...grpc.CallOption) (*CreateAccessTokenResponse, error)
+	ListAccessTokens(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListAccessTokensResponse, error)
+	RevokeAccessToken(ctx context.Context, in *RevokeAccessTokenRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	GetVaultParams(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetVaultParamsResponse, error)
+	SetVaultParams(ctx context.Context, in *VaultParams, opts ...grpc.CallOption) (*Empty, error)
+	EnrollTotp(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EnrollTotpResponse, error)
+	ConfirmTotp(ctx context.Context, in *ConfirmTotpRequest, opts ...grpc.CallOption) (*ConfirmTotpResponse, error)
+	DisableTotp(ctx context.Context, in *DisableTotpRequest, opts ...grpc.CallOption) (*Empty, error)
+	AdminUnlockLogin(ctx context.Context, in *UnlockLoginRequest, opts ...grpc.CallOption) (*UnlockLoginResponse, error)
+	AdminReencryptData(ctx context.Context, in *ReencryptRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReencryptProgress], error)
 	CreateData(ctx context.Context, in *CreateDataRequest, opts ...grpc.CallOption) (*CreateDataResponse, error)
-	GetDataList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetDataResponse, error)
+	GetDataList(ctx context.Context, in *GetDataListRequest, opts ...grpc.CallOption) (*GetDataResponse, error)
 	GetDataByID(ctx context.Context, in *GetDataByIDRequest, opts ...grpc.CallOption) (*GetDataByIDResponse, error)
 	UpdateData(ctx context.Context, in *UpdateDataRequest, opts ...grpc.CallOption) (*Empty, error)
 	DeleteData(ctx context.Context, in *DeleteDataRequest, opts ...grpc.CallOption) (*Empty, error)
+	MoveData(ctx context.Context, in *MoveDataRequest, opts ...grpc.CallOption) (*Empty, error)
+	AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*TagsResponse, error)
+	RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*TagsResponse, error)
+	CreateFolder(ctx context.Context, in *CreateFolderRequest, opts ...grpc.CallOption) (*CreateFolderResponse, error)
+	ListFolders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListFoldersResponse, error)
+	RenameFolder(ctx context.Context, in *RenameFolderRequest, opts ...grpc.CallOption) (*Empty, error)
+	MoveFolder(ctx context.Context, in *MoveFolderRequest, opts ...grpc.CallOption) (*Empty, error)
+	DeleteFolder(ctx context.Context, in *DeleteFolderRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListTrash(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListTrashResponse, error)
+	RestoreData(ctx context.Context, in *RestoreDataRequest, opts ...grpc.CallOption) (*Empty, error)
+	PurgeData(ctx context.Context, in *PurgeDataRequest, opts ...grpc.CallOption) (*Empty, error)
+	SealTrashedData(ctx context.Context, in *SealTrashedDataRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListVersions(ctx context.Context, in *ListVersionsRequest, opts ...grpc.CallOption) (*ListVersionsResponse, error)
+	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
+	RestoreVersion(ctx context.Context, in *RestoreVersionRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type passKeeperServiceClient struct {
@@ -69,6 +137,214 @@ func (c *passKeeperServiceClient) RegisterUser(ctx context.Context, in *UserAcco
 	return out, nil
 }
 
+func (c *passKeeperServiceClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*UserAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserAccountResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_RefreshToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) CompleteMfaLogin(ctx context.Context, in *MfaLoginRequest, opts ...grpc.CallOption) (*UserAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserAccountResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_CompleteMfaLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*UserAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserAccountResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_ChangePassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_DeleteAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) ExportAccount(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PassKeeperService_ServiceDesc.Streams[0], PassKeeperService_ExportAccount_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Empty, ExportChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PassKeeperService_ExportAccountClient = grpc.ServerStreamingClient[ExportChunk]
+
+func (c *passKeeperServiceClient) ListSessions(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_RevokeSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) CreateAccessToken(ctx context.Context, in *CreateAccessTokenRequest, opts ...grpc.CallOption) (*CreateAccessTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAccessTokenResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_CreateAccessToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) ListAccessTokens(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListAccessTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccessTokensResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_ListAccessTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) RevokeAccessToken(ctx context.Context, in *RevokeAccessTokenRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_RevokeAccessToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) GetVaultParams(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetVaultParamsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVaultParamsResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_GetVaultParams_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) SetVaultParams(ctx context.Context, in *VaultParams, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_SetVaultParams_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) EnrollTotp(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*EnrollTotpResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollTotpResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_EnrollTotp_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) ConfirmTotp(ctx context.Context, in *ConfirmTotpRequest, opts ...grpc.CallOption) (*ConfirmTotpResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmTotpResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_ConfirmTotp_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) DisableTotp(ctx context.Context, in *DisableTotpRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_DisableTotp_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) AdminUnlockLogin(ctx context.Context, in *UnlockLoginRequest, opts ...grpc.CallOption) (*UnlockLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlockLoginResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_AdminUnlockLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) AdminReencryptData(ctx context.Context, in *ReencryptRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReencryptProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PassKeeperService_ServiceDesc.Streams[1], PassKeeperService_AdminReencryptData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ReencryptRequest, ReencryptProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PassKeeperService_AdminReencryptDataClient = grpc.ServerStreamingClient[ReencryptProgress]
+
 func (c *passKeeperServiceClient) CreateData(ctx context.Context, in *CreateDataRequest, opts ...grpc.CallOption) (*CreateDataResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateDataResponse)
@@ -76,234 +352,1118 @@ func (c *passKeeperServiceClient) CreateData(ctx context.Context, in *CreateData
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) GetDataList(ctx context.Context, in *GetDataListRequest, opts ...grpc.CallOption) (*GetDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDataResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_GetDataList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) GetDataByID(ctx context.Context, in *GetDataByIDRequest, opts ...grpc.CallOption) (*GetDataByIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDataByIDResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_GetDataByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) UpdateData(ctx context.Context, in *UpdateDataRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_UpdateData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) DeleteData(ctx context.Context, in *DeleteDataRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_DeleteData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) MoveData(ctx context.Context, in *MoveDataRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_MoveData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*TagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagsResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_AddTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*TagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagsResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_RemoveTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) CreateFolder(ctx context.Context, in *CreateFolderRequest, opts ...grpc.CallOption) (*CreateFolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateFolderResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_CreateFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) ListFolders(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListFoldersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFoldersResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_ListFolders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) RenameFolder(ctx context.Context, in *RenameFolderRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_RenameFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) MoveFolder(ctx context.Context, in *MoveFolderRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_MoveFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) DeleteFolder(ctx context.Context, in *DeleteFolderRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_DeleteFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) ListTrash(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) RestoreData(ctx context.Context, in *RestoreDataRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_RestoreData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) PurgeData(ctx context.Context, in *PurgeDataRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_PurgeData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) SealTrashedData(ctx context.Context, in *SealTrashedDataRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_SealTrashedData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) ListVersions(ctx context.Context, in *ListVersionsRequest, opts ...grpc.CallOption) (*ListVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVersionsResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_ListVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVersionResponse)
+	err := c.cc.Invoke(ctx, PassKeeperService_GetVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *passKeeperServiceClient) RestoreVersion(ctx context.Context, in *RestoreVersionRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PassKeeperService_RestoreVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PassKeeperServiceServer is the server API for PassKeeperService service.
+// All implementations must embed UnimplementedPassKeeperServiceServer
+// for forward compatibility.
+type PassKeeperServiceServer interface {
+	AuthUser(context.Context, *UserAccountRequest) (*UserAccountResponse, error)
+	RegisterUser(context.Context, *UserAccountRequest) (*UserAccountResponse, error)
+	RefreshToken(context.Context, *RefreshTokenRequest) (*UserAccountResponse, error)
+	Logout(context.Context, *LogoutRequest) (*Empty, error)
+	CompleteMfaLogin(context.Context, *MfaLoginRequest) (*UserAccountResponse, error)
+	ChangePassword(context.Context, *ChangePasswordRequest) (*UserAccountResponse, error)
+	DeleteAccount(context.Context, *DeleteAccountRequest) (*Empty, error)
+	ExportAccount(*Empty, grpc.ServerStreamingServer[ExportChunk]) error
+	ListSessions(context.Context, *Empty) (*ListSessionsResponse, error)
+	RevokeSession(context.Context, *RevokeSessionRequest) (*Empty, error)
+	CreateAccessToken(context.Context, *CreateAccessTokenRequest) (*CreateAccessTokenResponse, error)
+	ListAccessTokens(context.Context, *Empty) (*ListAccessTokensResponse, error)
+	RevokeAccessToken(context.Context, *RevokeAccessTokenRequest) (*Empty, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	GetVaultParams(context.Context, *Empty) (*GetVaultParamsResponse, error)
+	SetVaultParams(context.Context, *VaultParams) (*Empty, error)
+	EnrollTotp(context.Context, *Empty) (*EnrollTotpResponse, error)
+	ConfirmTotp(context.Context, *ConfirmTotpRequest) (*ConfirmTotpResponse, error)
+	DisableTotp(context.Context, *DisableTotpRequest) (*Empty, error)
+	AdminUnlockLogin(context.Context, *UnlockLoginRequest) (*UnlockLoginResponse, error)
+	AdminReencryptData(*ReencryptRequest, grpc.ServerStreamingServer[ReencryptProgress]) error
+	CreateData(context.Context, *CreateDataRequest) (*CreateDataResponse, error)
+	GetDataList(context.Context, *GetDataListRequest) (*GetDataResponse, error)
+	GetDataByID(context.Context, *GetDataByIDRequest) (*GetDataByIDResponse, error)
+	UpdateData(context.Context, *UpdateDataRequest) (*Empty, error)
+	DeleteData(context.Context, *DeleteDataRequest) (*Empty, error)
+	MoveData(context.Context, *MoveDataRequest) (*Empty, error)
+	AddTags(context.Context, *AddTagsRequest) (*TagsResponse, error)
+	RemoveTags(context.Context, *RemoveTagsRequest) (*TagsResponse, error)
+	CreateFolder(context.Context, *CreateFolderRequest) (*CreateFolderResponse, error)
+	ListFolders(context.Context, *Empty) (*ListFoldersResponse, error)
+	RenameFolder(context.Context, *RenameFolderRequest) (*Empty, error)
+	MoveFolder(context.Context, *MoveFolderRequest) (*Empty, error)
+	DeleteFolder(context.Context, *DeleteFolderRequest) (*Empty, error)
+	ListTrash(context.Context, *Empty) (*ListTrashResponse, error)
+	RestoreData(context.Context, *RestoreDataRequest) (*Empty, error)
+	PurgeData(context.Context, *PurgeDataRequest) (*Empty, error)
+	SealTrashedData(context.Context, *SealTrashedDataRequest) (*Empty, error)
+	ListVersions(context.Context, *ListVersionsRequest) (*ListVersionsResponse, error)
+	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
+	RestoreVersion(context.Context, *RestoreVersionRequest) (*Empty, error)
+	mustEmbedUnimplementedPassKeeperServiceServer()
+}
+
+// UnimplementedPassKeeperServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPassKeeperServiceServer struct{}
+
+func (UnimplementedPassKeeperServiceServer) AuthUser(context.Context, *UserAccountRequest) (*UserAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthUser not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) RegisterUser(context.Context, *UserAccountRequest) (*UserAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterUser not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*UserAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) Logout(context.Context, *LogoutRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) CompleteMfaLogin(context.Context, *MfaLoginRequest) (*UserAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteMfaLogin not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*UserAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) ExportAccount(*Empty, grpc.ServerStreamingServer[ExportChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAccount not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) ListSessions(context.Context, *Empty) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) RevokeSession(context.Context, *RevokeSessionRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) CreateAccessToken(context.Context, *CreateAccessTokenRequest) (*CreateAccessTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccessToken not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) ListAccessTokens(context.Context, *Empty) (*ListAccessTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessTokens not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) RevokeAccessToken(context.Context, *RevokeAccessTokenRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAccessToken not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) GetVaultParams(context.Context, *Empty) (*GetVaultParamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVaultParams not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) SetVaultParams(context.Context, *VaultParams) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVaultParams not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) EnrollTotp(context.Context, *Empty) (*EnrollTotpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollTotp not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) ConfirmTotp(context.Context, *ConfirmTotpRequest) (*ConfirmTotpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTotp not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) DisableTotp(context.Context, *DisableTotpRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTotp not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) AdminUnlockLogin(context.Context, *UnlockLoginRequest) (*UnlockLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminUnlockLogin not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) AdminReencryptData(*ReencryptRequest, grpc.ServerStreamingServer[ReencryptProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method AdminReencryptData not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) CreateData(context.Context, *CreateDataRequest) (*CreateDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateData not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) GetDataList(context.Context, *GetDataListRequest) (*GetDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDataList not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) GetDataByID(context.Context, *GetDataByIDRequest) (*GetDataByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDataByID not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) UpdateData(context.Context, *UpdateDataRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateData not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) DeleteData(context.Context, *DeleteDataRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteData not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) MoveData(context.Context, *MoveDataRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveData not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) AddTags(context.Context, *AddTagsRequest) (*TagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTags not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) RemoveTags(context.Context, *RemoveTagsRequest) (*TagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTags not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) CreateFolder(context.Context, *CreateFolderRequest) (*CreateFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFolder not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) ListFolders(context.Context, *Empty) (*ListFoldersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFolders not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) RenameFolder(context.Context, *RenameFolderRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameFolder not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) MoveFolder(context.Context, *MoveFolderRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveFolder not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) DeleteFolder(context.Context, *DeleteFolderRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFolder not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) ListTrash(context.Context, *Empty) (*ListTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) RestoreData(context.Context, *RestoreDataRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreData not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) PurgeData(context.Context, *PurgeDataRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeData not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) SealTrashedData(context.Context, *SealTrashedDataRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SealTrashedData not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) ListVersions(context.Context, *ListVersionsRequest) (*ListVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVersions not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) RestoreVersion(context.Context, *RestoreVersionRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreVersion not implemented")
+}
+func (UnimplementedPassKeeperServiceServer) mustEmbedUnimplementedPassKeeperServiceServer() {}
+func (UnimplementedPassKeeperServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafePassKeeperServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PassKeeperServiceServer will
+// result in compilation errors.
+type UnsafePassKeeperServiceServer interface {
+	mustEmbedUnimplementedPassKeeperServiceServer()
+}
+
+func RegisterPassKeeperServiceServer(s grpc.ServiceRegistrar, srv PassKeeperServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPassKeeperServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PassKeeperService_ServiceDesc, srv)
+}
+
+func _PassKeeperService_AuthUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).AuthUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_AuthUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).AuthUser(ctx, req.(*UserAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_RegisterUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).RegisterUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_RegisterUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).RegisterUser(ctx, req.(*UserAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_CompleteMfaLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MfaLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).CompleteMfaLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_CompleteMfaLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).CompleteMfaLogin(ctx, req.(*MfaLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_DeleteAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_ExportAccount_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PassKeeperServiceServer).ExportAccount(m, &grpc.GenericServerStream[Empty, ExportChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PassKeeperService_ExportAccountServer = grpc.ServerStreamingServer[ExportChunk]
+
+func _PassKeeperService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).ListSessions(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_CreateAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccessTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).CreateAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_CreateAccessToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).CreateAccessToken(ctx, req.(*CreateAccessTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_ListAccessTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).ListAccessTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_ListAccessTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).ListAccessTokens(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_RevokeAccessToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAccessTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).RevokeAccessToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_RevokeAccessToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).RevokeAccessToken(ctx, req.(*RevokeAccessTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_GetVaultParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).GetVaultParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_GetVaultParams_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).GetVaultParams(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_SetVaultParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VaultParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).SetVaultParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_SetVaultParams_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).SetVaultParams(ctx, req.(*VaultParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_EnrollTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).EnrollTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_EnrollTotp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).EnrollTotp(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_ConfirmTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTotpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).ConfirmTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_ConfirmTotp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).ConfirmTotp(ctx, req.(*ConfirmTotpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_DisableTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableTotpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).DisableTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_DisableTotp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).DisableTotp(ctx, req.(*DisableTotpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_AdminUnlockLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).AdminUnlockLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_AdminUnlockLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).AdminUnlockLogin(ctx, req.(*UnlockLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_AdminReencryptData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReencryptRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PassKeeperServiceServer).AdminReencryptData(m, &grpc.GenericServerStream[ReencryptRequest, ReencryptProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PassKeeperService_AdminReencryptDataServer = grpc.ServerStreamingServer[ReencryptProgress]
+
+func _PassKeeperService_CreateData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).CreateData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_CreateData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).CreateData(ctx, req.(*CreateDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_GetDataList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDataListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).GetDataList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_GetDataList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).GetDataList(ctx, req.(*GetDataListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_GetDataByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDataByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).GetDataByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_GetDataByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).GetDataByID(ctx, req.(*GetDataByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_UpdateData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).UpdateData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_UpdateData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).UpdateData(ctx, req.(*UpdateDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_DeleteData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).DeleteData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_DeleteData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).DeleteData(ctx, req.(*DeleteDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PassKeeperService_MoveData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).MoveData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_MoveData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).MoveData(ctx, req.(*MoveDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *passKeeperServiceClient) GetDataList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetDataResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetDataResponse)
-	err := c.cc.Invoke(ctx, PassKeeperService_GetDataList_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _PassKeeperService_AddTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTagsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).AddTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_AddTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).AddTags(ctx, req.(*AddTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *passKeeperServiceClient) GetDataByID(ctx context.Context, in *GetDataByIDRequest, opts ...grpc.CallOption) (*GetDataByIDResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetDataByIDResponse)
-	err := c.cc.Invoke(ctx, PassKeeperService_GetDataByID_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _PassKeeperService_RemoveTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTagsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).RemoveTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_RemoveTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).RemoveTags(ctx, req.(*RemoveTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *passKeeperServiceClient) UpdateData(ctx context.Context, in *UpdateDataRequest, opts ...grpc.CallOption) (*Empty, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, PassKeeperService_UpdateData_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _PassKeeperService_CreateFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFolderRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).CreateFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_CreateFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).CreateFolder(ctx, req.(*CreateFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *passKeeperServiceClient) DeleteData(ctx context.Context, in *DeleteDataRequest, opts ...grpc.CallOption) (*Empty, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, PassKeeperService_DeleteData_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _PassKeeperService_ListFolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// PassKeeperServiceServer is the server API for PassKeeperService service.
-// All implementations must embed UnimplementedPassKeeperServiceServer
-// for forward compatibility.
-type PassKeeperServiceServer interface {
-	AuthUser(context.Context, *UserAccountRequest) (*UserAccountResponse, error)
-	RegisterUser(context.Context, *UserAccountRequest) (*UserAccountResponse, error)
-	CreateData(context.Context, *CreateDataRequest) (*CreateDataResponse, error)
-	GetDataList(context.Context, *Empty) (*GetDataResponse, error)
-	GetDataByID(context.Context, *GetDataByIDRequest) (*GetDataByIDResponse, error)
-	UpdateData(context.Context, *UpdateDataRequest) (*Empty, error)
-	DeleteData(context.Context, *DeleteDataRequest) (*Empty, error)
-	mustEmbedUnimplementedPassKeeperServiceServer()
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).ListFolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_ListFolders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).ListFolders(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedPassKeeperServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedPassKeeperServiceServer struct{}
-
-func (UnimplementedPassKeeperServiceServer) AuthUser(context.Context, *UserAccountRequest) (*UserAccountResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AuthUser not implemented")
-}
-func (UnimplementedPassKeeperServiceServer) RegisterUser(context.Context, *UserAccountRequest) (*UserAccountResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RegisterUser not implemented")
-}
-func (UnimplementedPassKeeperServiceServer) CreateData(context.Context, *CreateDataRequest) (*CreateDataResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateData not implemented")
-}
-func (UnimplementedPassKeeperServiceServer) GetDataList(context.Context, *Empty) (*GetDataResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDataList not implemented")
-}
-func (UnimplementedPassKeeperServiceServer) GetDataByID(context.Context, *GetDataByIDRequest) (*GetDataByIDResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDataByID not implemented")
-}
-func (UnimplementedPassKeeperServiceServer) UpdateData(context.Context, *UpdateDataRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateData not implemented")
-}
-func (UnimplementedPassKeeperServiceServer) DeleteData(context.Context, *DeleteDataRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteData not implemented")
+func _PassKeeperService_RenameFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).RenameFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_RenameFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).RenameFolder(ctx, req.(*RenameFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedPassKeeperServiceServer) mustEmbedUnimplementedPassKeeperServiceServer() {}
-func (UnimplementedPassKeeperServiceServer) testEmbeddedByValue()                           {}
 
-// UnsafePassKeeperServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to PassKeeperServiceServer will
-// result in compilation errors.
-type UnsafePassKeeperServiceServer interface {
-	mustEmbedUnimplementedPassKeeperServiceServer()
+func _PassKeeperService_MoveFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).MoveFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_MoveFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).MoveFolder(ctx, req.(*MoveFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterPassKeeperServiceServer(s grpc.ServiceRegistrar, srv PassKeeperServiceServer) {
-	// If the following call pancis, it indicates UnimplementedPassKeeperServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _PassKeeperService_DeleteFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&PassKeeperService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(PassKeeperServiceServer).DeleteFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PassKeeperService_DeleteFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PassKeeperServiceServer).DeleteFolder(ctx, req.(*DeleteFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _PassKeeperService_AuthUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserAccountRequest)
+func _PassKeeperService_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PassKeeperServiceServer).AuthUser(ctx, in)
+		return srv.(PassKeeperServiceServer).ListTrash(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PassKeeperService_AuthUser_FullMethodName,
+		FullMethod: PassKeeperService_ListTrash_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PassKeeperServiceServer).AuthUser(ctx, req.(*UserAccountRequest))
+		return srv.(PassKeeperServiceServer).ListTrash(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PassKeeperService_RegisterUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserAccountRequest)
+func _PassKeeperService_RestoreData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreDataRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PassKeeperServiceServer).RegisterUser(ctx, in)
+		return srv.(PassKeeperServiceServer).RestoreData(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PassKeeperService_RegisterUser_FullMethodName,
+		FullMethod: PassKeeperService_RestoreData_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PassKeeperServiceServer).RegisterUser(ctx, req.(*UserAccountRequest))
+		return srv.(PassKeeperServiceServer).RestoreData(ctx, req.(*RestoreDataRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PassKeeperService_CreateData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateDataRequest)
+func _PassKeeperService_PurgeData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDataRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PassKeeperServiceServer).CreateData(ctx, in)
+		return srv.(PassKeeperServiceServer).PurgeData(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PassKeeperService_CreateData_FullMethodName,
+		FullMethod: PassKeeperService_PurgeData_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PassKeeperServiceServer).CreateData(ctx, req.(*CreateDataRequest))
+		return srv.(PassKeeperServiceServer).PurgeData(ctx, req.(*PurgeDataRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PassKeeperService_GetDataList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _PassKeeperService_SealTrashedData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SealTrashedDataRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PassKeeperServiceServer).GetDataList(ctx, in)
+		return srv.(PassKeeperServiceServer).SealTrashedData(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PassKeeperService_GetDataList_FullMethodName,
+		FullMethod: PassKeeperService_SealTrashedData_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PassKeeperServiceServer).GetDataList(ctx, req.(*Empty))
+		return srv.(PassKeeperServiceServer).SealTrashedData(ctx, req.(*SealTrashedDataRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PassKeeperService_GetDataByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDataByIDRequest)
+func _PassKeeperService_ListVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVersionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PassKeeperServiceServer).GetDataByID(ctx, in)
+		return srv.(PassKeeperServiceServer).ListVersions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PassKeeperService_GetDataByID_FullMethodName,
+		FullMethod: PassKeeperService_ListVersions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PassKeeperServiceServer).GetDataByID(ctx, req.(*GetDataByIDRequest))
+		return srv.(PassKeeperServiceServer).ListVersions(ctx, req.(*ListVersionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PassKeeperService_UpdateData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateDataRequest)
+func _PassKeeperService_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PassKeeperServiceServer).UpdateData(ctx, in)
+		return srv.(PassKeeperServiceServer).GetVersion(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PassKeeperService_UpdateData_FullMethodName,
+		FullMethod: PassKeeperService_GetVersion_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PassKeeperServiceServer).UpdateData(ctx, req.(*UpdateDataRequest))
+		return srv.(PassKeeperServiceServer).GetVersion(ctx, req.(*GetVersionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PassKeeperService_DeleteData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteDataRequest)
+func _PassKeeperService_RestoreVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreVersionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PassKeeperServiceServer).DeleteData(ctx, in)
+		return srv.(PassKeeperServiceServer).RestoreVersion(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PassKeeperService_DeleteData_FullMethodName,
+		FullMethod: PassKeeperService_RestoreVersion_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PassKeeperServiceServer).DeleteData(ctx, req.(*DeleteDataRequest))
+		return srv.(PassKeeperServiceServer).RestoreVersion(ctx, req.(*RestoreVersionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -323,6 +1483,74 @@ var PassKeeperService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RegisterUser",
 			Handler:    _PassKeeperService_RegisterUser_Handler,
 		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _PassKeeperService_RefreshToken_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _PassKeeperService_Logout_Handler,
+		},
+		{
+			MethodName: "CompleteMfaLogin",
+			Handler:    _PassKeeperService_CompleteMfaLogin_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _PassKeeperService_ChangePassword_Handler,
+		},
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _PassKeeperService_DeleteAccount_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _PassKeeperService_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _PassKeeperService_RevokeSession_Handler,
+		},
+		{
+			MethodName: "CreateAccessToken",
+			Handler:    _PassKeeperService_CreateAccessToken_Handler,
+		},
+		{
+			MethodName: "ListAccessTokens",
+			Handler:    _PassKeeperService_ListAccessTokens_Handler,
+		},
+		{
+			MethodName: "RevokeAccessToken",
+			Handler:    _PassKeeperService_RevokeAccessToken_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _PassKeeperService_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "GetVaultParams",
+			Handler:    _PassKeeperService_GetVaultParams_Handler,
+		},
+		{
+			MethodName: "SetVaultParams",
+			Handler:    _PassKeeperService_SetVaultParams_Handler,
+		},
+		{
+			MethodName: "EnrollTotp",
+			Handler:    _PassKeeperService_EnrollTotp_Handler,
+		},
+		{
+			MethodName: "ConfirmTotp",
+			Handler:    _PassKeeperService_ConfirmTotp_Handler,
+		},
+		{
+			MethodName: "DisableTotp",
+			Handler:    _PassKeeperService_DisableTotp_Handler,
+		},
+		{
+			MethodName: "AdminUnlockLogin",
+			Handler:    _PassKeeperService_AdminUnlockLogin_Handler,
+		},
 		{
 			MethodName: "CreateData",
 			Handler:    _PassKeeperService_CreateData_Handler,
@@ -343,7 +1571,78 @@ var PassKeeperService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteData",
 			Handler:    _PassKeeperService_DeleteData_Handler,
 		},
+		{
+			MethodName: "MoveData",
+			Handler:    _PassKeeperService_MoveData_Handler,
+		},
+		{
+			MethodName: "AddTags",
+			Handler:    _PassKeeperService_AddTags_Handler,
+		},
+		{
+			MethodName: "RemoveTags",
+			Handler:    _PassKeeperService_RemoveTags_Handler,
+		},
+		{
+			MethodName: "CreateFolder",
+			Handler:    _PassKeeperService_CreateFolder_Handler,
+		},
+		{
+			MethodName: "ListFolders",
+			Handler:    _PassKeeperService_ListFolders_Handler,
+		},
+		{
+			MethodName: "RenameFolder",
+			Handler:    _PassKeeperService_RenameFolder_Handler,
+		},
+		{
+			MethodName: "MoveFolder",
+			Handler:    _PassKeeperService_MoveFolder_Handler,
+		},
+		{
+			MethodName: "DeleteFolder",
+			Handler:    _PassKeeperService_DeleteFolder_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _PassKeeperService_ListTrash_Handler,
+		},
+		{
+			MethodName: "RestoreData",
+			Handler:    _PassKeeperService_RestoreData_Handler,
+		},
+		{
+			MethodName: "PurgeData",
+			Handler:    _PassKeeperService_PurgeData_Handler,
+		},
+		{
+			MethodName: "SealTrashedData",
+			Handler:    _PassKeeperService_SealTrashedData_Handler,
+		},
+		{
+			MethodName: "ListVersions",
+			Handler:    _PassKeeperService_ListVersions_Handler,
+		},
+		{
+			MethodName: "GetVersion",
+			Handler:    _PassKeeperService_GetVersion_Handler,
+		},
+		{
+			MethodName: "RestoreVersion",
+			Handler:    _PassKeeperService_RestoreVersion_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportAccount",
+			Handler:       _PassKeeperService_ExportAccount_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AdminReencryptData",
+			Handler:       _PassKeeperService_AdminReencryptData_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/proto/proto.proto",
 }
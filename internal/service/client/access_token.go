@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrAccessTokenNotFound = errors.New("access token not found")
+)
+
+// CreateAccessToken creates a personal access token. The returned token string
+// is shown only once and cannot be retrieved later.
+func (service *ClientAuthService) CreateAccessToken(ctx context.Context, name string, expiresInDays uint32, scopes models.AccessTokenScopes) (string, error) {
+	var resp *pb.CreateAccessTokenResponse
+
+	pbScopes := &pb.AccessTokenScopes{
+		ReadOnly:  scopes.ReadOnly,
+		RecordIds: scopes.RecordIDs,
+	}
+	for _, dataType := range scopes.DataTypes {
+		pbScopes.DataTypes = append(pbScopes.DataTypes, pb.DataTypeEnum(pb.DataTypeEnum_value[string(dataType)]))
+	}
+
+	err := service.WithAuth(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = service.grpcClient.PBService.CreateAccessToken(ctx, &pb.CreateAccessTokenRequest{
+			Name:          name,
+			ExpiresInDays: expiresInDays,
+			Scopes:        pbScopes,
+		})
+		return err
+	})
+	if err != nil {
+		if e, ok := status.FromError(err); ok && e.Code() == codes.InvalidArgument {
+			return "", errors.New(e.Message())
+		}
+		return "", err
+	}
+
+	return resp.GetToken(), nil
+}
+
+func (service *ClientAuthService) ListAccessTokens(ctx context.Context) ([]models.AccessToken, error) {
+	var resp *pb.ListAccessTokensResponse
+
+	err := service.WithAuth(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = service.grpcClient.PBService.ListAccessTokens(ctx, &pb.Empty{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.AccessToken
+	for _, item := range resp.GetAccessTokens() {
+		expiresAt, _ := time.Parse(serverTimeLayout, item.GetExpiresAt())
+		createdAt, _ := time.Parse(serverTimeLayout, item.GetCreatedAt())
+
+		accessToken := models.AccessToken{
+			ID:        item.GetId(),
+			Name:      item.GetName(),
+			ExpiresAt: expiresAt,
+			CreatedAt: createdAt,
+			Scopes: models.AccessTokenScopes{
+				ReadOnly:  item.GetScopes().GetReadOnly(),
+				RecordIDs: item.GetScopes().GetRecordIds(),
+			},
+		}
+		for _, dataType := range item.GetScopes().GetDataTypes() {
+			accessToken.Scopes.DataTypes = append(accessToken.Scopes.DataTypes, models.DataTypeEnum(dataType.String()))
+		}
+		if lastUsedAt, err := time.Parse(serverTimeLayout, item.GetLastUsedAt()); err == nil {
+			accessToken.LastUsedAt = &lastUsedAt
+		}
+
+		result = append(result, accessToken)
+	}
+
+	return result, nil
+}
+
+func (service *ClientAuthService) RevokeAccessToken(ctx context.Context, tokenID uint32) error {
+	err := service.WithAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.RevokeAccessToken(ctx, &pb.RevokeAccessTokenRequest{Id: tokenID})
+		return err
+	})
+	if status.Code(err) == codes.NotFound {
+		return ErrAccessTokenNotFound
+	}
+	return err
+}
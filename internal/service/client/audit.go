@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListAuditEvents returns one page of the account audit log, newest first,
+// and the cursor of the next page. A zero cursor means there are no more events.
+func (service *ClientAuthService) ListAuditEvents(ctx context.Context, filter models.AuditFilter) ([]models.AuditEvent, uint64, error) {
+	var resp *pb.ListAuditEventsResponse
+
+	request := &pb.ListAuditEventsRequest{
+		EventTypes: filter.EventTypes,
+		PageSize:   uint32(filter.Limit),
+	}
+	if filter.From != nil {
+		request.From = filter.From.Format(serverTimeLayout)
+	}
+	if filter.To != nil {
+		request.To = filter.To.Format(serverTimeLayout)
+	}
+	if filter.BeforeID != 0 {
+		request.PageToken = strconv.FormatUint(filter.BeforeID, 10)
+	}
+
+	err := service.WithAuth(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = service.grpcClient.PBService.ListAuditEvents(ctx, request)
+		return err
+	})
+	if err != nil {
+		if e, ok := status.FromError(err); ok && e.Code() == codes.InvalidArgument {
+			return nil, 0, errors.New(e.Message())
+		}
+		return nil, 0, err
+	}
+
+	var result []models.AuditEvent
+	for _, item := range resp.GetEvents() {
+		createdAt, _ := time.Parse(serverTimeLayout, item.GetCreatedAt())
+
+		result = append(result, models.AuditEvent{
+			ID:            item.GetId(),
+			EventType:     item.GetEventType(),
+			RecordID:      item.GetRecordId(),
+			SessionID:     item.GetSessionId(),
+			AccessTokenID: item.GetAccessTokenId(),
+			PeerAddress:   item.GetPeerAddress(),
+			Details:       item.GetDetails(),
+			CreatedAt:     createdAt,
+		})
+	}
+
+	var nextCursor uint64
+	if resp.GetNextPageToken() != "" {
+		nextCursor, err = strconv.ParseUint(resp.GetNextPageToken(), 10, 64)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return result, nextCursor, nil
+}
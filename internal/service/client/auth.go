@@ -3,38 +3,52 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/bbquite/go-pass-keeper/internal/app/client"
 	"github.com/bbquite/go-pass-keeper/internal/models"
 	pb "github.com/bbquite/go-pass-keeper/internal/proto"
 	jwttoken "github.com/bbquite/go-pass-keeper/pkg/jwt_token"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 var (
 	ErrUserAlreadyExists  = errors.New("user already exists")
 	ErrIncorrectLoginData = errors.New("incorrect login or password")
+	ErrNoRefreshToken     = errors.New("no refresh token, run \"AUTH\"")
+	ErrNotAuthorized      = errors.New("authorization only, run \"AUTH\"")
+	ErrMfaRequired        = errors.New("two-factor authentication code required")
+	ErrIncorrectMfaCode   = errors.New("incorrect authentication code")
+	ErrLoginLocked        = errors.New("too many failed login attempts")
 )
 
 type clientAuthStorageRepo interface {
 	SetUserID(userID *uint32) error
 	SetToken(token *jwttoken.JWT) error
+	GetToken() string
+	GetRefreshToken() string
 	Debug() ([]byte, error)
 }
 
 type ClientAuthService struct {
-	grpcClient *client.GRPCClient
-	store      clientAuthStorageRepo
-	logger     *zap.SugaredLogger
+	grpcClient     *client.GRPCClient
+	store          clientAuthStorageRepo
+	onTokenRefresh func() error
+	mfaToken       string
+	deviceName     string
+	logger         *zap.SugaredLogger
 }
 
-func NewClientAuthService(grpcClient *client.GRPCClient, store clientAuthStorageRepo, logger *zap.SugaredLogger) *ClientAuthService {
+func NewClientAuthService(grpcClient *client.GRPCClient, store clientAuthStorageRepo, deviceName string, logger *zap.SugaredLogger) *ClientAuthService {
 
 	return &ClientAuthService{
 		grpcClient: grpcClient,
 		store:      store,
+		deviceName: deviceName,
 		logger:     logger.Named("CLIENT AUTH"),
 	}
 }
@@ -43,8 +57,9 @@ func (service *ClientAuthService) RegisterUser(ctx context.Context, userData *mo
 	var token jwttoken.JWT
 
 	resp, err := service.grpcClient.PBService.RegisterUser(ctx, &pb.UserAccountRequest{
-		Username: userData.Username,
-		Password: userData.Password,
+		Username:   userData.Username,
+		Password:   userData.Password,
+		DeviceName: service.deviceName,
 	})
 
 	if err != nil {
@@ -52,12 +67,15 @@ func (service *ClientAuthService) RegisterUser(ctx context.Context, userData *mo
 			switch e.Code() {
 			case codes.AlreadyExists:
 				return ErrUserAlreadyExists
+			case codes.InvalidArgument:
+				return errors.New(e.Message())
 			}
 		}
 		return err
 	}
 
 	token.Token = resp.GetToken()
+	token.RefreshToken = resp.GetRefreshToken()
 	err = service.store.SetToken(&token)
 	if err != nil {
 		return err
@@ -69,26 +87,247 @@ func (service *ClientAuthService) RegisterUser(ctx context.Context, userData *mo
 func (service *ClientAuthService) AuthUser(ctx context.Context, userData *models.UserAccountData) error {
 	var token jwttoken.JWT
 
+	var trailer metadata.MD
+
 	resp, err := service.grpcClient.PBService.AuthUser(ctx, &pb.UserAccountRequest{
-		Username: userData.Username,
-		Password: userData.Password,
-	})
+		Username:   userData.Username,
+		Password:   userData.Password,
+		DeviceName: service.deviceName,
+	}, grpc.Trailer(&trailer))
 
 	if err != nil {
 		if e, ok := status.FromError(err); ok {
 			switch e.Code() {
 			case codes.Unauthenticated:
 				return ErrIncorrectLoginData
+			case codes.ResourceExhausted:
+				return loginLockedError(trailer)
 			}
 		}
 		return err
 	}
 
+	if resp.GetMfaRequired() {
+		service.mfaToken = resp.GetMfaToken()
+		return ErrMfaRequired
+	}
+
+	token.Token = resp.GetToken()
+	token.RefreshToken = resp.GetRefreshToken()
+	err = service.store.SetToken(&token)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CompleteMfaLogin finishes a login interrupted by ErrMfaRequired with a TOTP or recovery code.
+func (service *ClientAuthService) CompleteMfaLogin(ctx context.Context, code string) error {
+	var token jwttoken.JWT
+
+	if service.mfaToken == "" {
+		return ErrNotAuthorized
+	}
+
+	var trailer metadata.MD
+
+	resp, err := service.grpcClient.PBService.CompleteMfaLogin(ctx, &pb.MfaLoginRequest{
+		MfaToken:   service.mfaToken,
+		Code:       code,
+		DeviceName: service.deviceName,
+	}, grpc.Trailer(&trailer))
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unauthenticated:
+			return ErrIncorrectMfaCode
+		case codes.ResourceExhausted:
+			return loginLockedError(trailer)
+		}
+		return err
+	}
+
+	service.mfaToken = ""
+	token.Token = resp.GetToken()
+	token.RefreshToken = resp.GetRefreshToken()
+	return service.store.SetToken(&token)
+}
+
+// ChangePassword changes the account password. The server invalidates every
+// other session and returns a new token pair for this one.
+func (service *ClientAuthService) ChangePassword(ctx context.Context, currentPassword string, newPassword string) error {
+	var token jwttoken.JWT
+	var resp *pb.UserAccountResponse
+	var trailer metadata.MD
+
+	err := service.WithAuth(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = service.grpcClient.PBService.ChangePassword(ctx, &pb.ChangePasswordRequest{
+			CurrentPassword: currentPassword,
+			NewPassword:     newPassword,
+		}, grpc.Trailer(&trailer))
+		return err
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.PermissionDenied:
+			return ErrIncorrectLoginData
+		case codes.ResourceExhausted:
+			return loginLockedError(trailer)
+		case codes.InvalidArgument:
+			return errors.New(status.Convert(err).Message())
+		}
+		return err
+	}
+
+	token.Token = resp.GetToken()
+	token.RefreshToken = resp.GetRefreshToken()
+	return service.store.SetToken(&token)
+}
+
+// DeleteAccount permanently deletes the account on the server and forgets the local token.
+func (service *ClientAuthService) DeleteAccount(ctx context.Context, password string, code string) error {
+	var trailer metadata.MD
+
+	err := service.WithAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.DeleteAccount(ctx, &pb.DeleteAccountRequest{
+			Password: password,
+			Code:     code,
+		}, grpc.Trailer(&trailer))
+		return err
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.PermissionDenied:
+			return fmt.Errorf("%w or authentication code", ErrIncorrectLoginData)
+		case codes.ResourceExhausted:
+			return loginLockedError(trailer)
+		}
+		return err
+	}
+
+	return service.store.SetToken(nil)
+}
+
+// IsAuthorized reports whether requests can be authenticated, either with a
+// stored access token or with the client certificate.
+func (service *ClientAuthService) IsAuthorized() bool {
+	return service.store.GetToken() != "" || service.grpcClient.HasClientCertificate()
+}
+
+// SetTokenHeader adds the access token to the outgoing request metadata. Without
+// a token the request relies on the client certificate when one is configured.
+func (service *ClientAuthService) SetTokenHeader(ctx context.Context) (context.Context, error) {
+	token := service.store.GetToken()
+	if token == "" {
+		if service.grpcClient.HasClientCertificate() {
+			return ctx, nil
+		}
+		return nil, fmt.Errorf("authorization only")
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token), nil
+}
+
+// WithAuth runs call with the authorization header set. When the server rejects
+// the access token, the token pair is refreshed once and the call is retried.
+func (service *ClientAuthService) WithAuth(ctx context.Context, call func(ctx context.Context) error) error {
+	authCtx, err := service.SetTokenHeader(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = call(authCtx)
+	if status.Code(err) != codes.Unauthenticated || service.store.GetRefreshToken() == "" {
+		return err
+	}
+
+	service.logger.Debug("access token rejected, refreshing")
+	refreshErr := service.RefreshToken(ctx)
+	if refreshErr != nil {
+		service.logger.Debugf("token refresh error: %v", refreshErr)
+		return err
+	}
+
+	authCtx, err = service.SetTokenHeader(ctx)
+	if err != nil {
+		return err
+	}
+
+	return call(authCtx)
+}
+
+// OnTokenRefresh registers a callback invoked after the token pair has been
+// rotated, so the caller can persist the new refresh token.
+func (service *ClientAuthService) OnTokenRefresh(fn func() error) {
+	service.onTokenRefresh = fn
+}
+
+func (service *ClientAuthService) RefreshToken(ctx context.Context) error {
+	var token jwttoken.JWT
+
+	refreshToken := service.store.GetRefreshToken()
+	if refreshToken == "" {
+		return ErrNoRefreshToken
+	}
+
+	resp, err := service.grpcClient.PBService.RefreshToken(ctx, &pb.RefreshTokenRequest{
+		RefreshToken: refreshToken,
+	})
+	if err != nil {
+		return err
+	}
+
 	token.Token = resp.GetToken()
+	token.RefreshToken = resp.GetRefreshToken()
 	err = service.store.SetToken(&token)
 	if err != nil {
 		return err
 	}
 
+	if service.onTokenRefresh != nil {
+		return service.onTokenRefresh()
+	}
+
 	return nil
 }
+
+// Logout revokes the current token pair on the server and forgets it locally.
+// When the server no longer accepts either token there is nothing left to
+// revoke, so the local state is cleared anyway.
+func (service *ClientAuthService) Logout(ctx context.Context) error {
+	if service.store.GetToken() == "" {
+		return ErrNotAuthorized
+	}
+
+	logout := func() error {
+		authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+service.store.GetToken())
+		_, err := service.grpcClient.PBService.Logout(authCtx, &pb.LogoutRequest{
+			RefreshToken: service.store.GetRefreshToken(),
+		})
+		return err
+	}
+
+	err := logout()
+	if status.Code(err) == codes.Unauthenticated {
+		if refreshErr := service.RefreshToken(ctx); refreshErr == nil {
+			err = logout()
+		} else {
+			service.logger.Debugf("token refresh on logout error: %v", refreshErr)
+			err = nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	return service.store.SetToken(nil)
+}
+
+func loginLockedError(trailer metadata.MD) error {
+	values := trailer.Get("retry-after")
+	if len(values) == 0 {
+		return ErrLoginLocked
+	}
+	return fmt.Errorf("%w, retry after %s seconds", ErrLoginLocked, values[0])
+}
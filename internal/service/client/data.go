@@ -3,7 +3,6 @@ package client
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/bbquite/go-pass-keeper/internal/app/client"
 	"github.com/bbquite/go-pass-keeper/internal/models"
@@ -11,13 +10,15 @@ import (
 	pb "github.com/bbquite/go-pass-keeper/internal/proto"
 	jwttoken "github.com/bbquite/go-pass-keeper/pkg/jwt_token"
 	"go.uber.org/zap"
-	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type clientDataStorageRepo interface {
 	SetUserID(userID *uint32) error
 	SetToken(token *jwttoken.JWT) error
 	GetToken() string
+	GetRefreshToken() string
 
 	AddPairs(data models.PairData) error
 	GetPairs() ([]models.PairData, error)
@@ -31,22 +32,29 @@ type clientDataStorageRepo interface {
 	AddCards(data models.CardData) error
 	GetCards() ([]models.CardData, error)
 
+	SetFolders(folders []models.Folder) error
+	GetFolders() ([]models.Folder, error)
+
 	Debug() ([]byte, error)
 	ClearStorage()
+	SaveCache() error
 }
 
 type ClientDataService struct {
-	grpcClient *client.GRPCClient
-	store      clientDataStorageRepo
-	logger     *zap.SugaredLogger
+	grpcClient  *client.GRPCClient
+	store       clientDataStorageRepo
+	authService *ClientAuthService
+	vault       vaultState
+	logger      *zap.SugaredLogger
 }
 
-func NewClientDataService(grpcClient *client.GRPCClient, store clientDataStorageRepo, logger *zap.SugaredLogger) *ClientDataService {
+func NewClientDataService(grpcClient *client.GRPCClient, store clientDataStorageRepo, authService *ClientAuthService, logger *zap.SugaredLogger) *ClientDataService {
 
 	return &ClientDataService{
-		grpcClient: grpcClient,
-		store:      store,
-		logger:     logger.Named("CLIENT DATA"),
+		grpcClient:  grpcClient,
+		store:       store,
+		authService: authService,
+		logger:      logger.Named("CLIENT DATA"),
 	}
 }
 
@@ -60,95 +68,134 @@ func (service *ClientDataService) Debug() error {
 }
 
 func (service *ClientDataService) SetTokenHeader(ctx context.Context) (context.Context, error) {
-	token := service.store.GetToken()
-	if token == "" {
-		return nil, fmt.Errorf("authorization only")
-	}
-
-	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+service.store.GetToken())
+	return service.authService.SetTokenHeader(ctx)
+}
 
-	return ctx, nil
+func (service *ClientDataService) withAuth(ctx context.Context, call func(ctx context.Context) error) error {
+	return service.authService.WithAuth(ctx, call)
 }
 
 func (service *ClientDataService) CreateData(ctx context.Context, data *models.DataStoreFormat) error {
 
-	ctx, err := service.SetTokenHeader(ctx)
+	pbData := converter.DataStoreFormatToProtoFormat(data)
+	err := service.sealValues(ctx, &pbData.DataInfo, &pbData.Meta)
 	if err != nil {
 		return err
 	}
 
-	pbData := converter.DataStoreFormatToProtoFormat(data)
-	_, err = service.grpcClient.PBService.CreateData(ctx, &pb.CreateDataRequest{Data: pbData})
+	err = service.withAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.CreateData(ctx, &pb.CreateDataRequest{Data: pbData})
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
+	service.syncCache(ctx)
 	return nil
 }
 
 func (service *ClientDataService) UpdateData(ctx context.Context, data *models.DataStoreFormat) error {
 
-	ctx, err := service.SetTokenHeader(ctx)
+	pbData := converter.DataStoreFormatToProtoFormat(data)
+	err := service.sealValues(ctx, &pbData.DataInfo, &pbData.Meta)
 	if err != nil {
 		return err
 	}
 
-	pbData := converter.DataStoreFormatToProtoFormat(data)
-	_, err = service.grpcClient.PBService.UpdateData(ctx, &pb.UpdateDataRequest{Data: pbData})
+	err = service.withAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.UpdateData(ctx, &pb.UpdateDataRequest{Data: pbData})
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
+	service.syncCache(ctx)
 	return nil
 }
 
 func (service *ClientDataService) DeleteData(ctx context.Context, dataID uint32) error {
 
-	ctx, err := service.SetTokenHeader(ctx)
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.DeleteData(ctx, &pb.DeleteDataRequest{Id: dataID})
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = service.grpcClient.PBService.DeleteData(ctx, &pb.DeleteDataRequest{Id: dataID})
-	return err
+	service.syncCache(ctx)
+	return nil
 }
 
 func (service *ClientDataService) GetDataByID(ctx context.Context, dataID uint32) (models.DataStoreFormat, error) {
 
 	var resultData models.DataStoreFormat
+	var response *pb.GetDataByIDResponse
 
-	ctx, err := service.SetTokenHeader(ctx)
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		var err error
+		response, err = service.grpcClient.PBService.GetDataByID(ctx, &pb.GetDataByIDRequest{Id: dataID})
+		return err
+	})
 	if err != nil {
 		return resultData, err
 	}
 
-	response, err := service.grpcClient.PBService.GetDataByID(ctx, &pb.GetDataByIDRequest{Id: dataID})
-	if err != nil {
-		return resultData, err
+	if response.Data != nil {
+		err = service.openValues(ctx, &response.Data.DataInfo, &response.Data.Meta)
+		if err != nil {
+			return resultData, err
+		}
+		err = service.openTags(ctx, response.Data.Tags)
+		if err != nil {
+			return resultData, err
+		}
 	}
 
 	resultData.ID = response.Data.GetId()
 	resultData.Meta = response.Data.GetMeta()
 	resultData.DataInfo = response.Data.GetDataInfo()
 	resultData.DataType = models.DataTypeEnum(response.Data.DataType)
+	resultData.FolderID = response.Data.GetFolderId()
+	resultData.Tags = response.Data.GetTags()
 
 	return resultData, nil
 }
 
 func (service *ClientDataService) GetData(ctx context.Context) error {
 
-	ctx, err := service.SetTokenHeader(ctx)
+	var response *pb.GetDataResponse
+
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		var err error
+		response, err = service.grpcClient.PBService.GetDataList(ctx, &pb.GetDataListRequest{})
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
-	response, err := service.grpcClient.PBService.GetDataList(ctx, &pb.Empty{})
+	folders, err := service.ListFolders(ctx)
 	if err != nil {
 		return err
 	}
 
-	service.store.ClearStorage()
 	dataItems := response.GetDataList()
+	for _, item := range dataItems {
+		err = service.openValues(ctx, &item.DataInfo, &item.Meta)
+		if err != nil {
+			return err
+		}
+		err = service.openTags(ctx, item.Tags)
+		if err != nil {
+			return err
+		}
+	}
+
+	service.store.ClearStorage()
+	service.store.SetFolders(folders)
 
 	for _, item := range dataItems {
 		switch item.DataType {
@@ -162,6 +209,8 @@ func (service *ClientDataService) GetData(ctx context.Context) error {
 			}
 			m.ID = item.Id
 			m.Meta = item.Meta
+			m.FolderID = item.FolderId
+			m.Tags = item.Tags
 			service.store.AddPairs(m)
 
 		case pb.DataTypeEnum(pb.DataTypeEnum_value[string(models.DataTypeTEXT)]):
@@ -174,6 +223,8 @@ func (service *ClientDataService) GetData(ctx context.Context) error {
 			}
 			m.ID = item.Id
 			m.Meta = item.Meta
+			m.FolderID = item.FolderId
+			m.Tags = item.Tags
 			service.store.AddTexts(m)
 
 		case pb.DataTypeEnum(pb.DataTypeEnum_value[string(models.DataTypeBINARY)]):
@@ -186,6 +237,8 @@ func (service *ClientDataService) GetData(ctx context.Context) error {
 			}
 			m.ID = item.Id
 			m.Meta = item.Meta
+			m.FolderID = item.FolderId
+			m.Tags = item.Tags
 			service.store.AddBinaries(m)
 
 		case pb.DataTypeEnum(pb.DataTypeEnum_value[string(models.DataTypeCARD)]):
@@ -198,9 +251,32 @@ func (service *ClientDataService) GetData(ctx context.Context) error {
 			}
 			m.ID = item.Id
 			m.Meta = item.Meta
+			m.FolderID = item.FolderId
+			m.Tags = item.Tags
 			service.store.AddCards(m)
 		}
 	}
 
+	err = service.store.SaveCache()
+	if err != nil {
+		service.logger.Warnf("saving local cache error: %v", err)
+	}
+
 	return nil
 }
+
+// syncCache refreshes the local cache after a change on the server. A failure
+// only leaves the cache stale until the next successful sync.
+func (service *ClientDataService) syncCache(ctx context.Context) {
+	err := service.GetData(ctx)
+	if err != nil {
+		service.logger.Debugf("local cache sync error: %v", err)
+	}
+}
+
+// IsServerUnavailable reports whether the call failed because the server
+// could not be reached, so cached data may be used instead.
+func IsServerUnavailable(err error) bool {
+	code := status.Code(err)
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
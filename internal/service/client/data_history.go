@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+)
+
+// ListVersions returns the previous versions of a record, newest first,
+// without their content.
+func (service *ClientDataService) ListVersions(ctx context.Context, dataID uint32) ([]models.DataVersion, error) {
+	var resp *pb.ListVersionsResponse
+
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = service.grpcClient.PBService.ListVersions(ctx, &pb.ListVersionsRequest{Id: dataID})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.DataVersion
+	for _, item := range resp.GetVersions() {
+		result = append(result, dataVersionFromProto(dataID, item))
+	}
+
+	return result, nil
+}
+
+func (service *ClientDataService) GetVersion(ctx context.Context, dataID uint32, version uint32) (models.DataVersion, error) {
+	var resp *pb.GetVersionResponse
+
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = service.grpcClient.PBService.GetVersion(ctx, &pb.GetVersionRequest{Id: dataID, Version: version})
+		return err
+	})
+	if err != nil {
+		return models.DataVersion{}, err
+	}
+
+	data := resp.GetData()
+	err = service.openValues(ctx, &data.DataInfo, &data.Meta)
+	if err != nil {
+		return models.DataVersion{}, err
+	}
+
+	result := dataVersionFromProto(dataID, resp.GetVersion())
+	result.Data.DataType = models.DataTypeEnum(data.GetDataType().String())
+	result.Data.DataInfo = data.GetDataInfo()
+	result.Data.Meta = data.GetMeta()
+
+	return result, nil
+}
+
+// RestoreVersion makes a previous version the current content of the record.
+func (service *ClientDataService) RestoreVersion(ctx context.Context, dataID uint32, version uint32) error {
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.RestoreVersion(ctx, &pb.RestoreVersionRequest{Id: dataID, Version: version})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	service.syncCache(ctx)
+	return nil
+}
+
+func dataVersionFromProto(dataID uint32, item *pb.DataVersion) models.DataVersion {
+	uploadedAt, _ := time.Parse(serverTimeLayout, item.GetUploadedAt())
+	archivedAt, _ := time.Parse(serverTimeLayout, item.GetArchivedAt())
+
+	return models.DataVersion{
+		Version:    item.GetVersion(),
+		Data:       models.DataStoreFormat{ID: dataID, UploadedAt: uploadedAt},
+		ArchivedAt: archivedAt,
+	}
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+)
+
+// ExportAccount downloads the full account archive into filePath.
+func (service *ClientDataService) ExportAccount(ctx context.Context, filePath string) error {
+	return service.withAuth(ctx, func(ctx context.Context) error {
+		stream, err := service.grpcClient.PBService.ExportAccount(ctx, &pb.Empty{})
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Create(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		for {
+			chunk, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+
+			_, err = file.Write(chunk.GetData())
+			if err != nil {
+				return err
+			}
+		}
+
+		return file.Close()
+	})
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+)
+
+func (service *ClientDataService) ListFolders(ctx context.Context) ([]models.Folder, error) {
+	var resp *pb.ListFoldersResponse
+
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = service.grpcClient.PBService.ListFolders(ctx, &pb.Empty{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.Folder
+	for _, item := range resp.GetFolders() {
+		err = service.openValues(ctx, &item.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		createdAt, _ := time.Parse(serverTimeLayout, item.GetCreatedAt())
+		result = append(result, models.Folder{
+			ID:        item.GetId(),
+			ParentID:  item.GetParentId(),
+			Name:      item.GetName(),
+			CreatedAt: createdAt,
+		})
+	}
+
+	return result, nil
+}
+
+// CreateFolder adds a folder under parentID, zero for the top level.
+func (service *ClientDataService) CreateFolder(ctx context.Context, name string, parentID uint32) error {
+	err := service.sealValues(ctx, &name)
+	if err != nil {
+		return err
+	}
+
+	err = service.withAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.CreateFolder(ctx, &pb.CreateFolderRequest{Name: name, ParentId: parentID})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	service.syncCache(ctx)
+	return nil
+}
+
+func (service *ClientDataService) RenameFolder(ctx context.Context, folderID uint32, name string) error {
+	err := service.sealValues(ctx, &name)
+	if err != nil {
+		return err
+	}
+
+	err = service.withAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.RenameFolder(ctx, &pb.RenameFolderRequest{Id: folderID, Name: name})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	service.syncCache(ctx)
+	return nil
+}
+
+// MoveFolder puts the folder under parentID, zero for the top level.
+func (service *ClientDataService) MoveFolder(ctx context.Context, folderID uint32, parentID uint32) error {
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.MoveFolder(ctx, &pb.MoveFolderRequest{Id: folderID, ParentId: parentID})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	service.syncCache(ctx)
+	return nil
+}
+
+// DeleteFolder removes the folder; its records and subfolders move up to its
+// parent.
+func (service *ClientDataService) DeleteFolder(ctx context.Context, folderID uint32) error {
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.DeleteFolder(ctx, &pb.DeleteFolderRequest{Id: folderID})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	service.syncCache(ctx)
+	return nil
+}
+
+// MoveData puts the record into folderID, zero for the top level.
+func (service *ClientDataService) MoveData(ctx context.Context, dataID uint32, folderID uint32) error {
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.MoveData(ctx, &pb.MoveDataRequest{Id: dataID, FolderId: folderID})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	service.syncCache(ctx)
+	return nil
+}
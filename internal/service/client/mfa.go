@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+)
+
+func (service *ClientAuthService) EnrollTotp(ctx context.Context) (models.TOTPEnrollment, error) {
+	var enrollment models.TOTPEnrollment
+	var resp *pb.EnrollTotpResponse
+
+	err := service.WithAuth(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = service.grpcClient.PBService.EnrollTotp(ctx, &pb.Empty{})
+		return err
+	})
+	if err != nil {
+		return enrollment, err
+	}
+
+	enrollment.Secret = resp.GetSecret()
+	enrollment.URI = resp.GetUri()
+	enrollment.QRCode = resp.GetQrCode()
+
+	return enrollment, nil
+}
+
+func (service *ClientAuthService) ConfirmTotp(ctx context.Context, code string) ([]string, error) {
+	var resp *pb.ConfirmTotpResponse
+
+	err := service.WithAuth(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = service.grpcClient.PBService.ConfirmTotp(ctx, &pb.ConfirmTotpRequest{Code: code})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetRecoveryCodes(), nil
+}
+
+func (service *ClientAuthService) DisableTotp(ctx context.Context, password string, code string) error {
+	return service.WithAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.DisableTotp(ctx, &pb.DisableTotpRequest{
+			Password: password,
+			Code:     code,
+		})
+		return err
+	})
+}
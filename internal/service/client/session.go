@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// serverTimeLayout matches the layout the server formats timestamps with.
+const serverTimeLayout = "2006-01-02 15:04:05"
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+func (service *ClientAuthService) ListSessions(ctx context.Context) ([]models.Session, error) {
+	var resp *pb.ListSessionsResponse
+
+	err := service.WithAuth(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = service.grpcClient.PBService.ListSessions(ctx, &pb.Empty{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.Session
+	for _, item := range resp.GetSessions() {
+		createdAt, _ := time.Parse(serverTimeLayout, item.GetCreatedAt())
+		lastSeenAt, _ := time.Parse(serverTimeLayout, item.GetLastSeenAt())
+
+		result = append(result, models.Session{
+			ID:          item.GetId(),
+			DeviceName:  item.GetDeviceName(),
+			PeerAddress: item.GetPeerAddress(),
+			UserAgent:   item.GetUserAgent(),
+			CreatedAt:   createdAt,
+			LastSeenAt:  lastSeenAt,
+			Current:     item.GetCurrent(),
+		})
+	}
+
+	return result, nil
+}
+
+func (service *ClientAuthService) RevokeSession(ctx context.Context, sessionID uint32) error {
+	err := service.WithAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.RevokeSession(ctx, &pb.RevokeSessionRequest{Id: sessionID})
+		return err
+	})
+	if status.Code(err) == codes.NotFound {
+		return ErrSessionNotFound
+	}
+	return err
+}
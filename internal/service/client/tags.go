@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	encryptor "github.com/bbquite/go-pass-keeper/internal/encryption"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+)
+
+// maxTagLength matches the server limit for plain tags. With the vault it is
+// checked here, before sealing, as the server only sees the sealed value.
+const maxTagLength = 255
+
+var ErrInvalidTags = errors.New("tags must be non-empty and up to 255 characters")
+
+// normalizeTags trims the tags and drops duplicates.
+func normalizeTags(tags []string) ([]string, error) {
+	var result []string
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || len(tag) > maxTagLength {
+			return nil, ErrInvalidTags
+		}
+		if !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result, nil
+}
+
+// AddTags adds the tags to the record. With the vault enabled every tag is
+// sealed on its own, so tags already on the record are skipped here, as the
+// server cannot compare them.
+func (service *ClientDataService) AddTags(ctx context.Context, dataID uint32, tags []string) error {
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return err
+	}
+
+	stored, err := service.storedTags(ctx, dataID)
+	if err != nil {
+		return err
+	}
+
+	var add []string
+	for _, tag := range tags {
+		if _, ok := stored[tag]; ok {
+			continue
+		}
+		err = service.sealValues(ctx, &tag)
+		if err != nil {
+			return err
+		}
+		add = append(add, tag)
+	}
+	if len(add) == 0 {
+		return nil
+	}
+
+	err = service.withAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.AddTags(ctx, &pb.AddTagsRequest{Id: dataID, Tags: add})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	service.syncCache(ctx)
+	return nil
+}
+
+// RemoveTags removes the tags from the record, sending the values as stored
+// so sealed tags match on the server.
+func (service *ClientDataService) RemoveTags(ctx context.Context, dataID uint32, tags []string) error {
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return err
+	}
+
+	stored, err := service.storedTags(ctx, dataID)
+	if err != nil {
+		return err
+	}
+
+	var remove []string
+	for _, tag := range tags {
+		if value, ok := stored[tag]; ok {
+			remove = append(remove, value)
+		}
+	}
+	if len(remove) == 0 {
+		return nil
+	}
+
+	err = service.withAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.RemoveTags(ctx, &pb.RemoveTagsRequest{Id: dataID, Tags: remove})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	service.syncCache(ctx)
+	return nil
+}
+
+// storedTags maps the opened tags of the record to the values the server
+// returns for them.
+func (service *ClientDataService) storedTags(ctx context.Context, dataID uint32) (map[string]string, error) {
+	var response *pb.GetDataByIDResponse
+
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		var err error
+		response, err = service.grpcClient.PBService.GetDataByID(ctx, &pb.GetDataByIDRequest{Id: dataID})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string)
+	for _, value := range response.GetData().GetTags() {
+		tag := value
+		err = service.openValues(ctx, &tag)
+		if err != nil {
+			return nil, err
+		}
+		result[tag] = value
+	}
+	return result, nil
+}
+
+// openTags decrypts vault sealed tags in place.
+func (service *ClientDataService) openTags(ctx context.Context, tags []string) error {
+	for i := range tags {
+		err := service.openValues(ctx, &tags[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sealTags replaces the tags of the record stored before the vault was
+// enabled with sealed ones and reports whether any were replaced. Sealed
+// copies are added before the plain tags are removed, and a tag already
+// sealed is not added twice, so it can be rerun after an interruption.
+func (service *ClientDataService) sealTags(ctx context.Context, item *pb.DataItem) (bool, error) {
+	var plain []string
+	sealedTags := make(map[string]bool)
+	for _, value := range item.GetTags() {
+		if !encryptor.IsVaultValue(value) {
+			plain = append(plain, value)
+			continue
+		}
+		tag := value
+		err := service.openValues(ctx, &tag)
+		if err != nil {
+			return false, err
+		}
+		sealedTags[tag] = true
+	}
+	if len(plain) == 0 {
+		return false, nil
+	}
+
+	// One tag at a time keeps the record within the tag limit.
+	for _, tag := range plain {
+		if !sealedTags[tag] {
+			sealedTag := tag
+			err := service.sealValues(ctx, &sealedTag)
+			if err != nil {
+				return false, err
+			}
+
+			err = service.withAuth(ctx, func(ctx context.Context) error {
+				_, err := service.grpcClient.PBService.AddTags(ctx, &pb.AddTagsRequest{Id: item.GetId(), Tags: []string{sealedTag}})
+				return err
+			})
+			if err != nil {
+				return false, err
+			}
+		}
+
+		err := service.withAuth(ctx, func(ctx context.Context) error {
+			_, err := service.grpcClient.PBService.RemoveTags(ctx, &pb.RemoveTagsRequest{Id: item.GetId(), Tags: []string{tag}})
+			return err
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
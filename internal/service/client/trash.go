@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	encryptor "github.com/bbquite/go-pass-keeper/internal/encryption"
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+)
+
+// ListTrash returns the deleted records, most recently deleted first.
+func (service *ClientDataService) ListTrash(ctx context.Context) ([]models.TrashItem, error) {
+	var resp *pb.ListTrashResponse
+
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = service.grpcClient.PBService.ListTrash(ctx, &pb.Empty{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.TrashItem
+	for _, item := range resp.GetItems() {
+		data := item.GetData()
+		err = service.openValues(ctx, &data.DataInfo, &data.Meta)
+		if err != nil {
+			return nil, err
+		}
+
+		deletedAt, _ := time.Parse(serverTimeLayout, item.GetDeletedAt())
+		result = append(result, models.TrashItem{
+			Data: models.DataStoreFormat{
+				ID:       data.GetId(),
+				DataType: models.DataTypeEnum(data.GetDataType().String()),
+				DataInfo: data.GetDataInfo(),
+				Meta:     data.GetMeta(),
+			},
+			DeletedAt: deletedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// RestoreData moves a deleted record out of the trash.
+func (service *ClientDataService) RestoreData(ctx context.Context, dataID uint32) error {
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.RestoreData(ctx, &pb.RestoreDataRequest{Id: dataID})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	service.syncCache(ctx)
+	return nil
+}
+
+// PurgeData permanently deletes a record in the trash.
+func (service *ClientDataService) PurgeData(ctx context.Context, dataID uint32) error {
+	return service.withAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.PurgeData(ctx, &pb.PurgeDataRequest{Id: dataID})
+		return err
+	})
+}
+
+// sealTrash encrypts the records in the trash stored before the vault was
+// enabled. They are sealed in place, so they stay in the trash and keep their
+// deletion time.
+func (service *ClientDataService) sealTrash(ctx context.Context) (int, error) {
+	var resp *pb.ListTrashResponse
+
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = service.grpcClient.PBService.ListTrash(ctx, &pb.Empty{})
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var sealed int
+	for _, trashItem := range resp.GetItems() {
+		item := trashItem.GetData()
+		if encryptor.IsVaultValue(item.GetDataInfo()) && encryptor.IsVaultValue(item.GetMeta()) &&
+			!slices.ContainsFunc(item.GetTags(), func(tag string) bool { return !encryptor.IsVaultValue(tag) }) {
+			continue
+		}
+
+		err = service.openValues(ctx, &item.DataInfo, &item.Meta)
+		if err != nil {
+			return sealed, err
+		}
+		err = service.sealValues(ctx, &item.DataInfo, &item.Meta)
+		if err != nil {
+			return sealed, err
+		}
+
+		// Tags are replaced as a whole, a plain tag that also has a sealed
+		// copy is dropped.
+		var tags []string
+		opened := make(map[string]bool)
+		for _, value := range item.GetTags() {
+			tag := value
+			err = service.openValues(ctx, &tag)
+			if err != nil {
+				return sealed, err
+			}
+			if opened[tag] {
+				continue
+			}
+			opened[tag] = true
+
+			if !encryptor.IsVaultValue(value) {
+				err = service.sealValues(ctx, &value)
+				if err != nil {
+					return sealed, err
+				}
+			}
+			tags = append(tags, value)
+		}
+		item.Tags = tags
+
+		err = service.withAuth(ctx, func(ctx context.Context) error {
+			_, err := service.grpcClient.PBService.SealTrashedData(ctx, &pb.SealTrashedDataRequest{Data: item})
+			return err
+		})
+		if err != nil {
+			return sealed, err
+		}
+		sealed++
+	}
+
+	return sealed, nil
+}
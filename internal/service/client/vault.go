@@ -0,0 +1,292 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+
+	encryptor "github.com/bbquite/go-pass-keeper/internal/encryption"
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	pb "github.com/bbquite/go-pass-keeper/internal/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrVaultLocked             = errors.New("vault is locked, run \"VAULT UNLOCK\"")
+	ErrVaultEnabled            = errors.New("end-to-end encryption already enabled")
+	ErrVaultNotEnabled         = errors.New("end-to-end encryption is not enabled, run \"VAULT ENABLE\"")
+	ErrIncorrectMasterPassword = errors.New("incorrect master password")
+)
+
+// defaultVaultParams is the Argon2id cost used for new vaults.
+var defaultVaultParams = models.VaultParams{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+}
+
+// vaultState caches the vault parameters of the logged in account and the
+// derived key once the vault is unlocked. The key never leaves the client.
+type vaultState struct {
+	params *models.VaultParams
+	key    *encryptor.Encryptor
+}
+
+// loadVault fetches the vault parameters once per login.
+func (service *ClientDataService) loadVault(ctx context.Context) (models.VaultParams, error) {
+	if service.vault.params != nil {
+		return *service.vault.params, nil
+	}
+
+	var resp *pb.GetVaultParamsResponse
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = service.grpcClient.PBService.GetVaultParams(ctx, &pb.Empty{})
+		return err
+	})
+	if err != nil {
+		return models.VaultParams{}, err
+	}
+
+	params := models.VaultParams{}
+	if resp.GetEnabled() {
+		params = models.VaultParams{
+			Salt:        resp.GetParams().GetSalt(),
+			Memory:      resp.GetParams().GetMemory(),
+			Iterations:  resp.GetParams().GetIterations(),
+			Parallelism: uint8(resp.GetParams().GetParallelism()),
+			Verifier:    resp.GetParams().GetVerifier(),
+		}
+
+		err = encryptor.ValidateVaultParams(params)
+		if err != nil {
+			return params, err
+		}
+	}
+
+	service.vault.params = &params
+	return params, nil
+}
+
+// VaultLocked reports whether the account uses end-to-end encryption and the
+// master password has not been entered yet.
+func (service *ClientDataService) VaultLocked(ctx context.Context) (bool, error) {
+	params, err := service.loadVault(ctx)
+	if err != nil {
+		return false, err
+	}
+	return params.Enabled() && service.vault.key == nil, nil
+}
+
+// UnlockVault derives the vault key from the master password.
+func (service *ClientDataService) UnlockVault(ctx context.Context, masterPassword string) error {
+	params, err := service.loadVault(ctx)
+	if err != nil {
+		return err
+	}
+	if !params.Enabled() {
+		return ErrVaultNotEnabled
+	}
+
+	key, err := encryptor.DeriveVaultKey(masterPassword, params)
+	if err != nil {
+		return err
+	}
+
+	vaultKey := encryptor.NewEncryptor(key)
+	if !vaultKey.CheckVaultVerifier(params.Verifier) {
+		return ErrIncorrectMasterPassword
+	}
+
+	service.vault.key = vaultKey
+	return nil
+}
+
+// LockVault forgets the vault key and parameters, e.g. on logout.
+func (service *ClientDataService) LockVault() {
+	service.vault = vaultState{}
+}
+
+// EnableVault turns on end-to-end encryption for the account with a new
+// master password. Existing records are then encrypted with SealRecords.
+func (service *ClientDataService) EnableVault(ctx context.Context, masterPassword string) error {
+	current, err := service.loadVault(ctx)
+	if err != nil {
+		return err
+	}
+	if current.Enabled() {
+		return ErrVaultEnabled
+	}
+
+	salt := make([]byte, encryptor.VaultSaltLength)
+	if _, err = rand.Read(salt); err != nil {
+		return err
+	}
+
+	params := defaultVaultParams
+	params.Salt = base64.StdEncoding.EncodeToString(salt)
+
+	key, err := encryptor.DeriveVaultKey(masterPassword, params)
+	if err != nil {
+		return err
+	}
+
+	vaultKey := encryptor.NewEncryptor(key)
+	params.Verifier, err = vaultKey.VaultVerifier()
+	if err != nil {
+		return err
+	}
+
+	err = service.withAuth(ctx, func(ctx context.Context) error {
+		_, err := service.grpcClient.PBService.SetVaultParams(ctx, &pb.VaultParams{
+			Salt:        params.Salt,
+			Memory:      params.Memory,
+			Iterations:  params.Iterations,
+			Parallelism: uint32(params.Parallelism),
+			Verifier:    params.Verifier,
+		})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			service.LockVault()
+			return ErrVaultEnabled
+		}
+		return err
+	}
+
+	service.vault = vaultState{params: &params, key: vaultKey}
+	return nil
+}
+
+// SealRecords encrypts the records, including those in the trash, and folder
+// names stored before the vault was enabled and returns how many were
+// updated. The server drops the history of a record when it is sealed. Already encrypted records are skipped, so it
+// can be rerun after an interruption.
+func (service *ClientDataService) SealRecords(ctx context.Context) (int, error) {
+	var response *pb.GetDataResponse
+
+	err := service.withAuth(ctx, func(ctx context.Context) error {
+		var err error
+		response, err = service.grpcClient.PBService.GetDataList(ctx, &pb.GetDataListRequest{})
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var sealed int
+	for _, item := range response.GetDataList() {
+		tagsSealed, err := service.sealTags(ctx, item)
+		if err != nil {
+			return sealed, err
+		}
+		if tagsSealed {
+			sealed++
+		}
+
+		if encryptor.IsVaultValue(item.GetDataInfo()) && encryptor.IsVaultValue(item.GetMeta()) {
+			continue
+		}
+
+		err = service.openValues(ctx, &item.DataInfo, &item.Meta)
+		if err != nil {
+			return sealed, err
+		}
+		err = service.sealValues(ctx, &item.DataInfo, &item.Meta)
+		if err != nil {
+			return sealed, err
+		}
+
+		err = service.withAuth(ctx, func(ctx context.Context) error {
+			_, err := service.grpcClient.PBService.UpdateData(ctx, &pb.UpdateDataRequest{Data: item})
+			return err
+		})
+		if err != nil {
+			return sealed, err
+		}
+		sealed++
+	}
+
+	trashSealed, err := service.sealTrash(ctx)
+	sealed += trashSealed
+	if err != nil {
+		return sealed, err
+	}
+
+	var folders *pb.ListFoldersResponse
+
+	err = service.withAuth(ctx, func(ctx context.Context) error {
+		var err error
+		folders, err = service.grpcClient.PBService.ListFolders(ctx, &pb.Empty{})
+		return err
+	})
+	if err != nil {
+		return sealed, err
+	}
+
+	for _, folder := range folders.GetFolders() {
+		if encryptor.IsVaultValue(folder.GetName()) {
+			continue
+		}
+
+		err = service.sealValues(ctx, &folder.Name)
+		if err != nil {
+			return sealed, err
+		}
+
+		err = service.withAuth(ctx, func(ctx context.Context) error {
+			_, err := service.grpcClient.PBService.RenameFolder(ctx, &pb.RenameFolderRequest{Id: folder.GetId(), Name: folder.GetName()})
+			return err
+		})
+		if err != nil {
+			return sealed, err
+		}
+		sealed++
+	}
+
+	return sealed, nil
+}
+
+// sealValues encrypts the values in place when the vault is enabled.
+func (service *ClientDataService) sealValues(ctx context.Context, values ...*string) error {
+	params, err := service.loadVault(ctx)
+	if err != nil {
+		return err
+	}
+	if !params.Enabled() {
+		return nil
+	}
+	if service.vault.key == nil {
+		return ErrVaultLocked
+	}
+
+	for _, value := range values {
+		*value, err = service.vault.key.SealVaultValue(*value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openValues decrypts vault ciphertext in place. Plain values are left as is.
+func (service *ClientDataService) openValues(ctx context.Context, values ...*string) error {
+	for _, value := range values {
+		if !encryptor.IsVaultValue(*value) {
+			continue
+		}
+		if service.vault.key == nil {
+			return ErrVaultLocked
+		}
+
+		plaintext, err := service.vault.key.OpenVaultValue(*value)
+		if err != nil {
+			return err
+		}
+		*value = plaintext
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	"github.com/bbquite/go-pass-keeper/internal/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	accessTokenSize   = 32
+	maxAccessTokenTTL = time.Hour * 24 * 365
+)
+
+var (
+	ErrInvalidAccessToken  = errors.New("invalid or expired access token")
+	ErrAccessTokenNotFound = errors.New("access token not found")
+	ErrInvalidTokenRequest = errors.New("access token name and expiry up to 365 days required")
+)
+
+type accessTokenStorageRepo interface {
+	CreateAccessToken(ctx context.Context, token *models.AccessToken) (models.AccessToken, error)
+	GetAccessTokenByHash(ctx context.Context, tokenHash string) (models.AccessToken, error)
+	GetAccountAccessTokens(ctx context.Context, accountID uint32) ([]models.AccessToken, error)
+	TouchAccessToken(ctx context.Context, tokenID uint32) error
+	RevokeAccessToken(ctx context.Context, accountID uint32, tokenID uint32) (bool, error)
+	GetDataByIDForUser(ctx context.Context, accountID uint32, storedDataID uint32) (models.DataStoreFormat, error)
+}
+
+// AccessTokenService manages long-lived personal access tokens for scripts.
+// Only the token hash is stored; the token itself is shown once on creation.
+type AccessTokenService struct {
+	store  accessTokenStorageRepo
+	audit  *AuditService
+	logger *zap.SugaredLogger
+}
+
+func NewAccessTokenService(store accessTokenStorageRepo, audit *AuditService, logger *zap.SugaredLogger) *AccessTokenService {
+	return &AccessTokenService{
+		store:  store,
+		audit:  audit,
+		logger: logger.Named("ACCESS TOKEN"),
+	}
+}
+
+func (s *AccessTokenService) CreateAccessToken(ctx context.Context, name string, expiresIn time.Duration, scopes models.AccessTokenScopes) (string, models.AccessToken, error) {
+	var result models.AccessToken
+
+	accountID, ok := ctx.Value(utils.AccountIDKey).(uint32)
+	if !ok {
+		return "", result, fmt.Errorf("invalid account ID format")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || expiresIn <= 0 || expiresIn > maxAccessTokenTTL {
+		return "", result, ErrInvalidTokenRequest
+	}
+
+	buf := make([]byte, accessTokenSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", result, err
+	}
+	token := models.AccessTokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+
+	result, err := s.store.CreateAccessToken(ctx, &models.AccessToken{
+		AccountID: accountID,
+		Name:      truncateSessionInfo(name),
+		TokenHash: utils.GenerateSHAString(token),
+		Scopes:    scopes,
+		ExpiresAt: time.Now().Add(expiresIn),
+	})
+	if err != nil {
+		return "", result, err
+	}
+
+	s.logger.Infof("access token %d created for account %d", result.ID, accountID)
+	s.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditAccessTokenCreate, Details: fmt.Sprintf("token %d %q", result.ID, result.Name)})
+	return token, result, nil
+}
+
+func (s *AccessTokenService) ListAccessTokens(ctx context.Context) ([]models.AccessToken, error) {
+	accountID, ok := ctx.Value(utils.AccountIDKey).(uint32)
+	if !ok {
+		return nil, fmt.Errorf("invalid account ID format")
+	}
+
+	return s.store.GetAccountAccessTokens(ctx, accountID)
+}
+
+func (s *AccessTokenService) RevokeAccessToken(ctx context.Context, tokenID uint32) error {
+	accountID, ok := ctx.Value(utils.AccountIDKey).(uint32)
+	if !ok {
+		return fmt.Errorf("invalid account ID format")
+	}
+
+	revoked, err := s.store.RevokeAccessToken(ctx, accountID, tokenID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrAccessTokenNotFound
+	}
+
+	s.logger.Infof("access token %d of account %d revoked", tokenID, accountID)
+	s.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditAccessTokenRevoke, Details: fmt.Sprintf("token %d", tokenID)})
+	return nil
+}
+
+// AuthenticateAccessToken resolves a presented token to its stored record.
+func (s *AccessTokenService) AuthenticateAccessToken(ctx context.Context, token string) (models.AccessToken, error) {
+	stored, err := s.store.GetAccessTokenByHash(ctx, utils.GenerateSHAString(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return stored, ErrInvalidAccessToken
+		}
+		return stored, err
+	}
+
+	if stored.RevokedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return stored, ErrInvalidAccessToken
+	}
+
+	err = s.store.TouchAccessToken(ctx, stored.ID)
+	if err != nil {
+		s.logger.Errorf("access token %d last used update error: %v", stored.ID, err)
+	}
+
+	return stored, nil
+}
+
+// GetRecordDataType returns the type of an account record, so data type
+// scopes can be checked for requests carrying only a record ID.
+func (s *AccessTokenService) GetRecordDataType(ctx context.Context, accountID uint32, recordID uint32) (models.DataTypeEnum, bool, error) {
+	data, err := s.store.GetDataByIDForUser(ctx, accountID, recordID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return data.DataType, true, nil
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	"github.com/bbquite/go-pass-keeper/internal/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	defAuditPageSize = 50
+	maxAuditPageSize = 500
+)
+
+var (
+	ErrInvalidAuditFilter = errors.New("invalid audit filter")
+)
+
+type auditStorageRepo interface {
+	CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error
+	GetAuditEvents(ctx context.Context, accountID uint32, filter *models.AuditFilter) ([]models.AuditEvent, error)
+}
+
+// AuditService writes the per-account audit log. Recording never fails the
+// audited operation: write errors are only logged.
+type AuditService struct {
+	store  auditStorageRepo
+	logger *zap.SugaredLogger
+}
+
+func NewAuditService(store auditStorageRepo, logger *zap.SugaredLogger) *AuditService {
+	return &AuditService{
+		store:  store,
+		logger: logger.Named("AUDIT"),
+	}
+}
+
+// Record stores an event for accountID. The peer address, session and access
+// token are taken from the request context unless already set on the event.
+func (s *AuditService) Record(ctx context.Context, accountID uint32, event models.AuditEvent) {
+	event.AccountID = accountID
+	event.PeerAddress = truncateSessionInfo(utils.GetPeerIP(ctx))
+	event.Details = truncateSessionInfo(event.Details)
+
+	if event.SessionID == 0 {
+		event.SessionID, _ = ctx.Value(utils.SessionIDKey).(uint32)
+	}
+	if event.AccessTokenID == 0 {
+		event.AccessTokenID, _ = ctx.Value(utils.AccessTokenIDKey).(uint32)
+	}
+
+	err := s.store.CreateAuditEvent(ctx, &event)
+	if err != nil {
+		s.logger.Errorf("audit event %s for account %d error: %v", event.EventType, accountID, err)
+	}
+}
+
+// ListAuditEvents returns one page of the caller's audit events, newest first,
+// and the cursor of the next page, which is zero on the last page.
+func (s *AuditService) ListAuditEvents(ctx context.Context, filter models.AuditFilter) ([]models.AuditEvent, uint64, error) {
+	accountID, ok := ctx.Value(utils.AccountIDKey).(uint32)
+	if !ok {
+		return nil, 0, fmt.Errorf("invalid account ID format")
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, 0, ErrInvalidAuditFilter
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defAuditPageSize
+	}
+	if filter.Limit > maxAuditPageSize {
+		filter.Limit = maxAuditPageSize
+	}
+
+	// One extra row tells whether another page exists.
+	pageSize := filter.Limit
+	filter.Limit++
+
+	events, err := s.store.GetAuditEvents(ctx, accountID, &filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var nextCursor uint64
+	if len(events) > pageSize {
+		events = events[:pageSize]
+		nextCursor = events[pageSize-1].ID
+	}
+
+	return events, nextCursor, nil
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"time"
+
+	encryptor "github.com/bbquite/go-pass-keeper/internal/encryption"
 	"github.com/bbquite/go-pass-keeper/internal/models"
 	"github.com/bbquite/go-pass-keeper/internal/utils"
 	jwttoken "github.com/bbquite/go-pass-keeper/pkg/jwt_token"
@@ -13,49 +17,106 @@ import (
 var (
 	ErrUserAlreadyExists  = errors.New("user already exists")
 	ErrIncorrectLoginData = errors.New("incorrect login or password")
+
+	ErrTokenRequired = errors.New("operation requires a token login")
+
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
 )
 
 type authStorageRepo interface {
 	CreateAccount(ctx context.Context, username string, password string) (uint32, error)
 	GetAccountByUsername(ctx context.Context, username string) (models.Account, error)
-	GetAccountByLoginData(ctx context.Context, username string, password string) (models.Account, error)
+	GetAccountByID(ctx context.Context, accountID uint32) (models.Account, error)
+	UpdateAccountPassword(ctx context.Context, accountID uint32, password string) error
+	ChangeAccountPassword(ctx context.Context, accountID uint32, password string) (uint32, error)
+	GetAccountTokenGeneration(ctx context.Context, accountID uint32) (uint32, error)
+	DeleteAccount(ctx context.Context, accountID uint32) error
+
+	GetAccountTOTP(ctx context.Context, accountID uint32) (models.AccountTOTP, error)
+	SetAccountTOTPSecret(ctx context.Context, accountID uint32, secret string) error
+	EnableAccountTOTP(ctx context.Context, accountID uint32, recoveryCodeHashes []string) error
+	DisableAccountTOTP(ctx context.Context, accountID uint32) error
+	UpdateTOTPLastStep(ctx context.Context, accountID uint32, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, accountID uint32, codeHash string) (bool, error)
+
+	CreateRefreshToken(ctx context.Context, accountID uint32, familyID string, tokenHash string, expiresAt time.Time) error
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (models.RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, tokenID uint32) (bool, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeAccountRefreshTokens(ctx context.Context, accountID uint32) error
+
+	CreateSession(ctx context.Context, session *models.Session) (models.Session, error)
+	GetSessionByID(ctx context.Context, accountID uint32, sessionID uint32) (models.Session, error)
+	GetSessionByFamily(ctx context.Context, familyID string) (models.Session, error)
+	GetAccountSessions(ctx context.Context, accountID uint32) ([]models.Session, error)
+	RevokeSession(ctx context.Context, accountID uint32, sessionID uint32) error
+	RevokeOtherSessions(ctx context.Context, accountID uint32, exceptSessionID uint32) ([]uint32, error)
 }
 
 type AuthService struct {
 	store      authStorageRepo
 	logger     *zap.SugaredLogger
 	jwtManager *jwttoken.JWTManager
+	hasher     PasswordHasher
+	policy     *PasswordPolicy
+	revocation *RevocationService
+	encryptor  *encryptor.Encryptor
+	limiter    *LoginLimiter
+	dataKeys   *DataKeyService
+	audit      *AuditService
 }
 
-func NewAuthService(store authStorageRepo, jwtManager *jwttoken.JWTManager, logger *zap.SugaredLogger) *AuthService {
+func NewAuthService(store authStorageRepo, jwtManager *jwttoken.JWTManager, hasher PasswordHasher, policy *PasswordPolicy, revocation *RevocationService, encryptorManager *encryptor.Encryptor, limiter *LoginLimiter, dataKeys *DataKeyService, audit *AuditService, logger *zap.SugaredLogger) *AuthService {
 	return &AuthService{
 		store:      store,
 		logger:     logger.Named("AUTH"),
 		jwtManager: jwtManager,
+		hasher:     hasher,
+		policy:     policy,
+		revocation: revocation,
+		encryptor:  encryptorManager,
+		limiter:    limiter,
+		dataKeys:   dataKeys,
+		audit:      audit,
 	}
 }
 
 func (service *AuthService) RegisterUser(ctx context.Context, userData *models.UserAccountData) (jwttoken.JWT, error) {
 	var token jwttoken.JWT
 
-	_, err := service.store.GetAccountByUsername(ctx, userData.Username)
+	err := service.policy.Check(userData.Username, userData.Password)
+	if err != nil {
+		return token, err
+	}
+
+	_, err = service.store.GetAccountByUsername(ctx, userData.Username)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 
-			userID, err := service.store.CreateAccount(
-				ctx, userData.Username, utils.GenerateSHAString(userData.Password))
+			passwordHash, err := service.hasher.Hash(userData.Password)
 			if err != nil {
 				return token, err
 			}
 
-			tokenString, err := service.jwtManager.CreateAccessToken(userID)
+			userID, err := service.store.CreateAccount(ctx, userData.Username, passwordHash)
+			if err != nil {
+				return token, err
+			}
 
+			err = service.dataKeys.CreateDataKey(ctx, userID)
 			if err != nil {
 				return token, err
 			}
 
-			token.Token = tokenString
-			return token, nil
+			session, err := service.startSession(ctx, userID, userData.DeviceName)
+			if err != nil {
+				return token, err
+			}
+
+			service.audit.Record(ctx, userID, models.AuditEvent{EventType: models.AuditRegister, SessionID: session.ID})
+
+			return service.issueTokenPair(ctx, session)
 		}
 		return token, err
 	}
@@ -65,21 +126,420 @@ func (service *AuthService) RegisterUser(ctx context.Context, userData *models.U
 func (service *AuthService) AuthUser(ctx context.Context, userData *models.UserAccountData) (jwttoken.JWT, error) {
 	var token jwttoken.JWT
 
-	shaInputPassword := utils.GenerateSHAString(userData.Password)
+	ip := utils.GetPeerIP(ctx)
+
+	err := service.limiter.Check(ctx, userData.Username, ip)
+	if err != nil {
+		return token, err
+	}
+
+	account, err := service.checkAccountPassword(ctx, userData.Username, userData.Password)
+	if err != nil {
+		if errors.Is(err, ErrIncorrectLoginData) {
+			service.registerLoginFailure(ctx, userData.Username, ip)
+			if account.ID != 0 {
+				service.audit.Record(ctx, account.ID, models.AuditEvent{EventType: models.AuditLoginFailed, Details: "password"})
+			}
+		}
+		return token, err
+	}
+
+	// With 2FA enabled the failure counter is reset only after the second step,
+	// so knowing the password does not allow unlimited code guessing.
+	if account.TOTPEnabled {
+		mfaToken, err := service.jwtManager.CreateMfaToken(account.ID)
+		if err != nil {
+			return token, err
+		}
+		token.MfaToken = mfaToken
+		return token, nil
+	}
+
+	service.registerLoginSuccess(ctx, userData.Username, ip)
+
+	session, err := service.startSession(ctx, account.ID, userData.DeviceName)
+	if err != nil {
+		return token, err
+	}
+
+	service.audit.Record(ctx, account.ID, models.AuditEvent{EventType: models.AuditLogin, SessionID: session.ID})
+
+	return service.issueTokenPair(ctx, session)
+}
+
+// ChangePassword replaces the account password after checking the current one.
+// All previously issued access and refresh tokens stop working and every other
+// device session is revoked; the returned pair continues the current session.
+func (service *AuthService) ChangePassword(ctx context.Context, currentPassword string, newPassword string) (jwttoken.JWT, error) {
+	var token jwttoken.JWT
+
+	accountID, err := service.getAccountIDFromContext(ctx)
+	if err != nil {
+		return token, err
+	}
+
+	sessionID, err := service.getSessionIDFromContext(ctx)
+	if err != nil {
+		return token, err
+	}
+
+	account, err := service.store.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return token, err
+	}
+
+	ip := utils.GetPeerIP(ctx)
+
+	err = service.limiter.Check(ctx, account.Username, ip)
+	if err != nil {
+		return token, err
+	}
+
+	_, err = service.checkAccountPassword(ctx, account.Username, currentPassword)
+	if err != nil {
+		if errors.Is(err, ErrIncorrectLoginData) {
+			service.registerLoginFailure(ctx, account.Username, ip)
+			service.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditLoginFailed, Details: "change password"})
+		}
+		return token, err
+	}
+	service.releaseLoginAttempt(ctx, account.Username, ip)
+
+	err = service.policy.Check(account.Username, newPassword)
+	if err != nil {
+		return token, err
+	}
+
+	passwordHash, err := service.hasher.Hash(newPassword)
+	if err != nil {
+		return token, err
+	}
+
+	generation, err := service.store.ChangeAccountPassword(ctx, accountID, passwordHash)
+	if err != nil {
+		return token, err
+	}
+	service.revocation.SetTokenGeneration(accountID, generation)
+
+	err = service.store.RevokeAccountRefreshTokens(ctx, accountID)
+	if err != nil {
+		return token, err
+	}
+
+	revokedSessions, err := service.store.RevokeOtherSessions(ctx, accountID, sessionID)
+	if err != nil {
+		return token, err
+	}
+	for _, revokedID := range revokedSessions {
+		service.revocation.MarkSessionRevoked(revokedID)
+	}
+
+	service.logger.Infof("password changed for account %d, token generation %d", accountID, generation)
+	service.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditPasswordChange})
+
+	session, err := service.store.GetSessionByID(ctx, accountID, sessionID)
+	if err != nil {
+		return token, err
+	}
+
+	return service.issueTokenPair(ctx, session)
+}
+
+// DeleteAccount permanently removes the account with all its data after
+// re-checking the password and, when 2FA is enabled, a second factor code.
+func (service *AuthService) DeleteAccount(ctx context.Context, password string, code string) error {
+	accountID, err := service.getAccountIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	account, err := service.store.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	ip := utils.GetPeerIP(ctx)
+
+	err = service.limiter.Check(ctx, account.Username, ip)
+	if err != nil {
+		return err
+	}
+
+	_, err = service.checkAccountPassword(ctx, account.Username, password)
+	if err != nil {
+		if errors.Is(err, ErrIncorrectLoginData) {
+			service.registerLoginFailure(ctx, account.Username, ip)
+			service.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditLoginFailed, Details: "delete account"})
+		}
+		return err
+	}
+
+	if account.TOTPEnabled {
+		err = service.verifySecondFactor(ctx, accountID, code)
+		if err != nil {
+			if errors.Is(err, ErrIncorrectMfaCode) {
+				service.registerLoginFailure(ctx, account.Username, ip)
+				service.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditLoginFailed, Details: "delete account second factor"})
+			}
+			return err
+		}
+	}
+	service.releaseLoginAttempt(ctx, account.Username, ip)
+
+	err = service.store.DeleteAccount(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	service.dataKeys.ForgetDataKey(accountID)
+
+	service.logger.Infof("account %d deleted", accountID)
+	return nil
+}
+
+// UnlockLogin clears failed login attempts and lockouts for a username and/or IP.
+func (service *AuthService) UnlockLogin(ctx context.Context, username string, ip string) (bool, error) {
+	unlocked, err := service.limiter.Unlock(ctx, username, ip)
+	if err != nil {
+		return false, err
+	}
+
+	service.logger.Infof("login unlock for username %q ip %q: %v", username, ip, unlocked)
+	return unlocked, nil
+}
+
+func (service *AuthService) registerLoginFailure(ctx context.Context, username string, ip string) {
+	err := service.limiter.RegisterFailure(ctx, username, ip)
+	if err != nil {
+		service.logger.Errorf("register login failure error: %v", err)
+	}
+}
+
+func (service *AuthService) registerLoginSuccess(ctx context.Context, username string, ip string) {
+	err := service.limiter.RegisterSuccess(ctx, username, ip)
+	if err != nil {
+		service.logger.Errorf("register login success error: %v", err)
+	}
+}
+
+func (service *AuthService) releaseLoginAttempt(ctx context.Context, username string, ip string) {
+	err := service.limiter.Release(ctx, username, ip)
+	if err != nil {
+		service.logger.Errorf("release login attempt error: %v", err)
+	}
+}
+
+// RefreshToken exchanges a refresh token for a new access+refresh pair.
+// Every refresh token is single use: presenting an already used one revokes
+// the whole family, since either the client or an attacker holds a stolen copy.
+func (service *AuthService) RefreshToken(ctx context.Context, refreshToken string) (jwttoken.JWT, error) {
+	var token jwttoken.JWT
+
+	stored, err := service.store.GetRefreshTokenByHash(ctx, utils.GenerateSHAString(refreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return token, ErrInvalidRefreshToken
+		}
+		return token, err
+	}
+
+	if stored.RevokedAt != nil {
+		return token, ErrInvalidRefreshToken
+	}
+
+	if stored.UsedAt != nil {
+		return token, service.revokeReusedFamily(ctx, stored)
+	}
+
+	if time.Now().After(stored.ExpiresAt) {
+		return token, ErrInvalidRefreshToken
+	}
+
+	marked, err := service.store.MarkRefreshTokenUsed(ctx, stored.ID)
+	if err != nil {
+		return token, err
+	}
+	if !marked {
+		return token, service.revokeReusedFamily(ctx, stored)
+	}
 
-	account, err := service.store.GetAccountByLoginData(ctx, userData.Username, shaInputPassword)
+	session, err := service.getFamilySession(ctx, stored.AccountID, stored.FamilyID)
+	if err != nil {
+		return token, err
+	}
+	if session.RevokedAt != nil {
+		return token, ErrInvalidRefreshToken
+	}
+
+	service.audit.Record(ctx, session.AccountID, models.AuditEvent{EventType: models.AuditTokenRefresh, SessionID: session.ID})
+
+	return service.issueTokenPair(ctx, session)
+}
+
+// Logout revokes the access token from the request context together with its
+// device session and, when given, the refresh token family it was issued with.
+func (service *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	accountID, tokenID, expiresAt, err := service.getTokenFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = service.revocation.RevokeToken(ctx, tokenID, accountID, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	sessionID, err := service.getSessionIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = service.store.RevokeSession(ctx, accountID, sessionID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	service.revocation.MarkSessionRevoked(sessionID)
+
+	service.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditLogout})
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	stored, err := service.store.GetRefreshTokenByHash(ctx, utils.GenerateSHAString(refreshToken))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return token, ErrIncorrectLoginData
+			return nil
 		}
+		return err
+	}
+
+	if stored.AccountID != accountID {
+		return nil
+	}
+
+	return service.store.RevokeRefreshTokenFamily(ctx, stored.FamilyID)
+}
+
+func (service *AuthService) getAccountIDFromContext(ctx context.Context) (uint32, error) {
+	accountID, ok := ctx.Value(utils.AccountIDKey).(uint32)
+	if !ok {
+		return 0, fmt.Errorf("invalid account ID format")
+	}
+	return accountID, nil
+}
+
+func (service *AuthService) getTokenFromContext(ctx context.Context) (uint32, string, time.Time, error) {
+	accountID, err := service.getAccountIDFromContext(ctx)
+	if err != nil {
+		return 0, "", time.Time{}, err
+	}
+
+	// Requests authenticated by a client certificate carry no token.
+	tokenID, ok := ctx.Value(utils.TokenIDKey).(string)
+	if !ok {
+		return 0, "", time.Time{}, ErrTokenRequired
+	}
+
+	expiresAt, ok := ctx.Value(utils.TokenExpiryKey).(time.Time)
+	if !ok {
+		return 0, "", time.Time{}, fmt.Errorf("invalid token expiry format")
+	}
+
+	return accountID, tokenID, expiresAt, nil
+}
+
+func (service *AuthService) revokeReusedFamily(ctx context.Context, stored models.RefreshToken) error {
+	service.logger.Warnf("refresh token reuse for account %d, revoking family %s", stored.AccountID, stored.FamilyID)
+	service.audit.Record(ctx, stored.AccountID, models.AuditEvent{EventType: models.AuditTokenReuse})
+
+	err := service.store.RevokeRefreshTokenFamily(ctx, stored.FamilyID)
+	if err != nil {
+		return err
+	}
+
+	session, err := service.store.GetSessionByFamily(ctx, stored.FamilyID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRefreshTokenReused
+		}
+		return err
+	}
+
+	err = service.store.RevokeSession(ctx, session.AccountID, session.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	service.revocation.MarkSessionRevoked(session.ID)
+
+	return ErrRefreshTokenReused
+}
+
+// issueTokenPair creates an access token bound to the session and a refresh
+// token belonging to the session refresh token family.
+func (service *AuthService) issueTokenPair(ctx context.Context, session models.Session) (jwttoken.JWT, error) {
+	var token jwttoken.JWT
+
+	generation, err := service.store.GetAccountTokenGeneration(ctx, session.AccountID)
+	if err != nil {
+		return token, err
+	}
+
+	accessToken, err := service.jwtManager.CreateAccessToken(session.AccountID, generation, session.ID)
+	if err != nil {
 		return token, err
 	}
 
-	tokenString, err := service.jwtManager.CreateAccessToken(account.ID)
+	refreshToken, expiresAt, err := service.jwtManager.CreateRefreshToken()
 	if err != nil {
 		return token, err
 	}
 
-	token.Token = tokenString
+	err = service.store.CreateRefreshToken(ctx, session.AccountID, session.FamilyID, utils.GenerateSHAString(refreshToken), expiresAt)
+	if err != nil {
+		return token, err
+	}
+
+	token.Token = accessToken
+	token.RefreshToken = refreshToken
 	return token, nil
 }
+
+// checkAccountPassword verifies the password for username and transparently
+// upgrades the stored hash when the hasher reports it as outdated.
+func (service *AuthService) checkAccountPassword(ctx context.Context, username string, password string) (models.Account, error) {
+	account, err := service.store.GetAccountByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// same work as a wrong password, so usernames cannot be probed
+			service.hasher.Verify(password, service.hasher.DummyHash())
+			return account, ErrIncorrectLoginData
+		}
+		return account, err
+	}
+
+	ok, needsRehash, err := service.hasher.Verify(password, account.Password)
+	if err != nil {
+		return account, err
+	}
+	if !ok {
+		return account, ErrIncorrectLoginData
+	}
+
+	if needsRehash {
+		passwordHash, err := service.hasher.Hash(password)
+		if err != nil {
+			service.logger.Errorf("password rehash error for account %d: %v", account.ID, err)
+			return account, nil
+		}
+
+		err = service.store.UpdateAccountPassword(ctx, account.ID, passwordHash)
+		if err != nil {
+			service.logger.Errorf("password rehash update error for account %d: %v", account.ID, err)
+			return account, nil
+		}
+		account.Password = passwordHash
+		service.logger.Infof("password hash upgraded for account %d", account.ID)
+	}
+
+	return account, nil
+}
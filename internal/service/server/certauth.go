@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	"go.uber.org/zap"
+)
+
+// Certificate fields a client certificate identity can be taken from.
+const (
+	CertIdentityCN    = "cn"
+	CertIdentityEmail = "email"
+	CertIdentityDNS   = "dns"
+	CertIdentityURI   = "uri"
+)
+
+var (
+	ErrCertificateNotMapped = errors.New("client certificate is not mapped to an account")
+)
+
+type certificateStorageRepo interface {
+	GetAccountByUsername(ctx context.Context, username string) (models.Account, error)
+}
+
+// CertificateAuthenticator maps a verified client certificate to the account
+// whose username equals the configured certificate field, so service accounts
+// can call the API without a password login.
+type CertificateAuthenticator struct {
+	store         certificateStorageRepo
+	identityField string
+	logger        *zap.SugaredLogger
+}
+
+func NewCertificateAuthenticator(store certificateStorageRepo, identityField string, logger *zap.SugaredLogger) (*CertificateAuthenticator, error) {
+	switch identityField {
+	case CertIdentityCN, CertIdentityEmail, CertIdentityDNS, CertIdentityURI:
+	default:
+		return nil, fmt.Errorf("unknown client certificate identity field %q", identityField)
+	}
+
+	return &CertificateAuthenticator{
+		store:         store,
+		identityField: identityField,
+		logger:        logger.Named("CERT AUTH"),
+	}, nil
+}
+
+// AuthenticateCertificate returns the account ID for a certificate that has
+// already been verified against the client CA. With several SAN values the
+// first one matching an account wins.
+func (a *CertificateAuthenticator) AuthenticateCertificate(ctx context.Context, cert *x509.Certificate) (uint32, error) {
+	for _, identity := range a.identities(cert) {
+		if identity == "" {
+			continue
+		}
+
+		account, err := a.store.GetAccountByUsername(ctx, identity)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return 0, err
+		}
+
+		a.logger.Debugf("client certificate %q authenticated as account %d", identity, account.ID)
+		return account.ID, nil
+	}
+
+	return 0, ErrCertificateNotMapped
+}
+
+func (a *CertificateAuthenticator) identities(cert *x509.Certificate) []string {
+	switch a.identityField {
+	case CertIdentityEmail:
+		return cert.EmailAddresses
+	case CertIdentityDNS:
+		return cert.DNSNames
+	case CertIdentityURI:
+		var result []string
+		for _, uri := range cert.URIs {
+			result = append(result, uri.String())
+		}
+		return result
+	}
+	return []string{cert.Subject.CommonName}
+}
@@ -11,34 +11,98 @@ import (
 
 type dataStorageRepo interface {
 	CreateData(ctx context.Context, accountID uint32, data *models.DataStoreFormat) (models.DataStoreFormat, error)
-	GetDataList(ctx context.Context, accountID uint32) ([]models.DataStoreFormat, error)
-	UpdateData(ctx context.Context, accountID uint32, data *models.DataStoreFormat) error
+	GetDataList(ctx context.Context, accountID uint32, filter models.DataFilter) ([]models.DataStoreFormat, error)
+	UpdateData(ctx context.Context, accountID uint32, data *models.DataStoreFormat, keepVersions int) error
+	UpdateDataTags(ctx context.Context, accountID uint32, dataID uint32, modify func(tags []string) ([]string, error)) error
 	DeleteData(ctx context.Context, accountID uint32, dataID uint32) error
+	MoveData(ctx context.Context, accountID uint32, dataID uint32, folderID uint32) error
 
 	GetDataByIDForUser(ctx context.Context, accountID uint32, storedDataID uint32) (models.DataStoreFormat, error)
+
+	GetDataVersions(ctx context.Context, accountID uint32, dataID uint32) ([]models.DataVersion, error)
+	GetDataVersion(ctx context.Context, accountID uint32, dataID uint32, version uint32) (models.DataVersion, error)
+	RestoreDataVersion(ctx context.Context, accountID uint32, dataID uint32, version uint32, keepVersions int) error
+	GetAccountDataHistory(ctx context.Context, accountID uint32) ([]models.DataVersion, error)
+
+	GetTrash(ctx context.Context, accountID uint32) ([]models.TrashItem, error)
+	RestoreTrashedData(ctx context.Context, accountID uint32, dataID uint32) error
+	PurgeTrashedData(ctx context.Context, accountID uint32, dataID uint32) error
+	SealTrashedData(ctx context.Context, accountID uint32, data *models.DataStoreFormat) error
+
+	CreateFolder(ctx context.Context, accountID uint32, folder *models.Folder) (models.Folder, error)
+	GetFolders(ctx context.Context, accountID uint32) ([]models.Folder, error)
+	RenameFolder(ctx context.Context, accountID uint32, folderID uint32, name string) error
+	MoveFolder(ctx context.Context, accountID uint32, folderID uint32, parentID uint32) (bool, error)
+	DeleteFolder(ctx context.Context, accountID uint32, folderID uint32) error
+
+	GetAccountByID(ctx context.Context, accountID uint32) (models.Account, error)
+	GetAccountRefreshTokens(ctx context.Context, accountID uint32) ([]models.RefreshToken, error)
+	GetAuditEvents(ctx context.Context, accountID uint32, filter *models.AuditFilter) ([]models.AuditEvent, error)
 }
 
+// DataService encrypts records with the data key of their account. Updates
+// keep the last keepVersions versions of a record.
 type DataService struct {
-	store     dataStorageRepo
-	encryptor *encryptor.Encryptor
-	logger    *zap.SugaredLogger
+	store        dataStorageRepo
+	dataKeys     *DataKeyService
+	audit        *AuditService
+	keepVersions int
+	logger       *zap.SugaredLogger
 }
 
-func NewDataService(store dataStorageRepo, encryptorManager *encryptor.Encryptor, logger *zap.SugaredLogger) *DataService {
+func NewDataService(store dataStorageRepo, dataKeys *DataKeyService, audit *AuditService, keepVersions int, logger *zap.SugaredLogger) *DataService {
 	return &DataService{
-		store:     store,
-		encryptor: encryptorManager,
-		logger:    logger.Named("DATA"),
+		store:        store,
+		dataKeys:     dataKeys,
+		audit:        audit,
+		keepVersions: keepVersions,
+		logger:       logger.Named("DATA"),
+	}
+}
+
+const (
+	recordFieldInfo = "data_info"
+	recordFieldMeta = "meta"
+	recordFieldTags = "tags"
+)
+
+// recordAD is the associated data of a record field. It binds the ciphertext
+// to the account, data type and field, so a value moved to another row or
+// account no longer decrypts.
+func recordAD(accountID uint32, dataType models.DataTypeEnum, field string) []byte {
+	return []byte(fmt.Sprintf("go-pass-keeper/record|%d|%s|%s", accountID, dataType, field))
+}
+
+// openRecordField decrypts a record field. Fields sealed before associated
+// data was used are opened with the legacy keys without it; bound reports
+// false for them.
+func openRecordField(dataKey *encryptor.Encryptor, legacy []*encryptor.Encryptor, ciphertext string, ad []byte) (string, bool, error) {
+	plaintext, err := dataKey.DecryptWithAD(ciphertext, ad)
+	if err == nil {
+		return plaintext, true, nil
+	}
+
+	for _, key := range legacy {
+		plaintext, legacyErr := key.Decrypt(ciphertext)
+		if legacyErr == nil {
+			return plaintext, false, nil
+		}
 	}
+	return "", false, err
 }
 
-func (s *DataService) EncryptData(data *models.DataStoreFormat) (*models.DataStoreFormat, error) {
-	encryptedInfo, err := s.encryptor.Encrypt(data.DataInfo)
+func (s *DataService) EncryptData(ctx context.Context, data *models.DataStoreFormat) (*models.DataStoreFormat, error) {
+	accountID, dataKey, err := s.getDataKey(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	encryptedMeta, err := s.encryptor.Encrypt(data.Meta)
+	encryptedInfo, err := dataKey.EncryptWithAD(data.DataInfo, recordAD(accountID, data.DataType, recordFieldInfo))
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedMeta, err := dataKey.EncryptWithAD(data.Meta, recordAD(accountID, data.DataType, recordFieldMeta))
 	if err != nil {
 		return nil, err
 	}
@@ -49,23 +113,53 @@ func (s *DataService) EncryptData(data *models.DataStoreFormat) (*models.DataSto
 	return data, nil
 }
 
-func (s *DataService) DecryptData(data *models.DataStoreFormat) (*models.DataStoreFormat, error) {
-	decryptedInfo, err := s.encryptor.Decrypt(data.DataInfo)
+func (s *DataService) DecryptData(ctx context.Context, data *models.DataStoreFormat) (*models.DataStoreFormat, error) {
+	accountID, dataKey, err := s.getDataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	legacy, err := s.dataKeys.legacyKeys(ctx, accountID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	decryptedInfo, infoBound, err := openRecordField(dataKey, legacy, data.DataInfo, recordAD(accountID, data.DataType, recordFieldInfo))
 	if err != nil {
 		return nil, err
 	}
 
-	decryptedMeta, err := s.encryptor.Decrypt(data.Meta)
+	decryptedMeta, metaBound, err := openRecordField(dataKey, legacy, data.Meta, recordAD(accountID, data.DataType, recordFieldMeta))
+	if err != nil {
+		return nil, err
+	}
+
+	if !infoBound || !metaBound {
+		s.logger.Warnf("data ID %d is not bound to its account yet, run the re-encryption job", data.ID)
+	}
+
+	decryptedTags, err := openTags(dataKey, accountID, data.DataType, data.Tags)
 	if err != nil {
 		return nil, err
 	}
 
 	data.DataInfo = decryptedInfo
 	data.Meta = decryptedMeta
+	data.Tags = decryptedTags
 
 	return data, nil
 }
 
+func (s *DataService) getDataKey(ctx context.Context) (uint32, *encryptor.Encryptor, error) {
+	accountID, err := s.getAccountIDFromContext(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	dataKey, err := s.dataKeys.GetDataKey(ctx, accountID)
+	return accountID, dataKey, err
+}
+
 func (s *DataService) getAccountIDFromContext(ctx context.Context) (uint32, error) {
 	accountIDKey := ctx.Value(utils.AccountIDKey)
 	accountID, ok := accountIDKey.(uint32)
@@ -83,7 +177,7 @@ func (s *DataService) CreateData(ctx context.Context, data *models.DataStoreForm
 		return resultData, err
 	}
 
-	encryptedData, err := s.EncryptData(data)
+	encryptedData, err := s.EncryptData(ctx, data)
 	if err != nil {
 		return resultData, fmt.Errorf("encryption error: %v", err)
 	}
@@ -93,10 +187,15 @@ func (s *DataService) CreateData(ctx context.Context, data *models.DataStoreForm
 		return resultData, err
 	}
 
+	s.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditDataCreate, RecordID: resultData.ID})
+
 	return resultData, nil
 }
 
-func (s *DataService) GetDataList(ctx context.Context) ([]models.DataStoreFormat, error) {
+// GetDataList returns the decrypted records selected by the filter. Tags are
+// encrypted, so the tag filter applies after decryption. Tags sealed with the
+// vault stay opaque here and never match; vault clients filter on their side.
+func (s *DataService) GetDataList(ctx context.Context, filter models.DataFilter) ([]models.DataStoreFormat, error) {
 	var resultDataList []models.DataStoreFormat
 
 	accountID, err := s.getAccountIDFromContext(ctx)
@@ -104,7 +203,7 @@ func (s *DataService) GetDataList(ctx context.Context) ([]models.DataStoreFormat
 		return resultDataList, err
 	}
 
-	resultDataList, err = s.store.GetDataList(ctx, accountID)
+	resultDataList, err = s.store.GetDataList(ctx, accountID, filter)
 	if err != nil {
 		return resultDataList, err
 	}
@@ -112,10 +211,13 @@ func (s *DataService) GetDataList(ctx context.Context) ([]models.DataStoreFormat
 	var decryptedDataList []models.DataStoreFormat
 
 	for _, item := range resultDataList {
-		dd, err := s.DecryptData(&item)
+		dd, err := s.DecryptData(ctx, &item)
 		if err != nil {
+			return nil, fmt.Errorf("decryption error for data ID %d: %v", item.ID, err)
+		}
+
+		if !hasTags(dd.Tags, filter.Tags) {
 			continue
-			// return resultDataList, fmt.Errorf("decryption error: %v", err)
 		}
 
 		decryptedDataList = append(decryptedDataList, *dd)
@@ -137,11 +239,13 @@ func (s *DataService) GetDataByID(ctx context.Context, dataID uint32) (models.Da
 		return resultData, err
 	}
 
-	decryptedData, err := s.DecryptData(&resultData)
+	decryptedData, err := s.DecryptData(ctx, &resultData)
 	if err != nil {
 		return resultData, fmt.Errorf("encryption error: %v", err)
 	}
 
+	s.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditDataRead, RecordID: dataID})
+
 	return *decryptedData, nil
 }
 
@@ -151,21 +255,36 @@ func (s *DataService) UpdateData(ctx context.Context, data *models.DataStoreForm
 		return err
 	}
 
-	_, err = s.store.GetDataByIDForUser(ctx, accountID, data.ID)
+	current, err := s.store.GetDataByIDForUser(ctx, accountID, data.ID)
 	if err != nil {
 		return err
 	}
 
-	encryptedData, err := s.EncryptData(data)
+	// Sealing a record with the vault drops its history instead of archiving
+	// more content the server can read.
+	keepVersions := s.keepVersions
+	if encryptor.IsVaultValue(data.DataInfo) {
+		_, err = s.DecryptData(ctx, &current)
+		if err != nil {
+			return fmt.Errorf("decryption error: %v", err)
+		}
+		if !encryptor.IsVaultValue(current.DataInfo) || !encryptor.IsVaultValue(current.Meta) {
+			keepVersions = 0
+		}
+	}
+
+	encryptedData, err := s.EncryptData(ctx, data)
 	if err != nil {
 		return fmt.Errorf("encryption error: %v", err)
 	}
 
-	err = s.store.UpdateData(ctx, accountID, encryptedData)
+	err = s.store.UpdateData(ctx, accountID, encryptedData, keepVersions)
 	if err != nil {
 		return err
 	}
 
+	s.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditDataUpdate, RecordID: data.ID})
+
 	return nil
 }
 
@@ -185,5 +304,61 @@ func (s *DataService) DeleteData(ctx context.Context, dataID uint32) error {
 		return err
 	}
 
+	s.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditDataDelete, RecordID: dataID})
+
+	return nil
+}
+
+// ListVersions returns the previous versions of a record, newest first,
+// without their content.
+func (s *DataService) ListVersions(ctx context.Context, dataID uint32) ([]models.DataVersion, error) {
+	accountID, err := s.getAccountIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.store.GetDataByIDForUser(ctx, accountID, dataID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.GetDataVersions(ctx, accountID, dataID)
+}
+
+func (s *DataService) GetVersion(ctx context.Context, dataID uint32, version uint32) (models.DataVersion, error) {
+	accountID, err := s.getAccountIDFromContext(ctx)
+	if err != nil {
+		return models.DataVersion{}, err
+	}
+
+	result, err := s.store.GetDataVersion(ctx, accountID, dataID, version)
+	if err != nil {
+		return result, err
+	}
+
+	_, err = s.DecryptData(ctx, &result.Data)
+	if err != nil {
+		return result, fmt.Errorf("decryption error: %v", err)
+	}
+
+	s.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditDataRead, RecordID: dataID, Details: fmt.Sprintf("version %d", version)})
+
+	return result, nil
+}
+
+// RestoreVersion makes a previous version the current content of the record.
+func (s *DataService) RestoreVersion(ctx context.Context, dataID uint32, version uint32) error {
+	accountID, err := s.getAccountIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.RestoreDataVersion(ctx, accountID, dataID, version, s.keepVersions)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditDataRestore, RecordID: dataID, Details: fmt.Sprintf("version %d", version)})
+
 	return nil
 }
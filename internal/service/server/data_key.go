@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	encryptor "github.com/bbquite/go-pass-keeper/internal/encryption"
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	"go.uber.org/zap"
+)
+
+const dataKeySize = 32
+
+type dataKeyStorageRepo interface {
+	GetAccountDataKey(ctx context.Context, accountID uint32) (models.AccountDataKey, error)
+	CreateAccountDataKey(ctx context.Context, key *models.AccountDataKey) (models.AccountDataKey, error)
+}
+
+type cachedDataKey struct {
+	key    *encryptor.Encryptor
+	legacy bool
+}
+
+// DataKeyService manages per-account data encryption keys (DEKs). A DEK is
+// generated at registration and stored wrapped by the server master key, so a
+// leaked DEK exposes a single account. Unwrapped keys are cached in memory.
+// Until the re-encryption job has bound every record, fields without
+// associated data are still accepted.
+type DataKeyService struct {
+	store        dataKeyStorageRepo
+	master       *encryptor.Encryptor
+	cache        map[uint32]cachedDataKey
+	recordsBound atomic.Bool
+	mx           sync.RWMutex
+	logger       *zap.SugaredLogger
+}
+
+func NewDataKeyService(store dataKeyStorageRepo, master *encryptor.Encryptor, logger *zap.SugaredLogger) *DataKeyService {
+	return &DataKeyService{
+		store:  store,
+		master: master,
+		cache:  make(map[uint32]cachedDataKey),
+		logger: logger.Named("DATA KEY"),
+	}
+}
+
+// CreateDataKey generates the DEK of a new account. It is a no-op when the
+// account already has one.
+func (s *DataKeyService) CreateDataKey(ctx context.Context, accountID uint32) error {
+	_, err := s.createDataKey(ctx, accountID, false)
+	return err
+}
+
+// GetDataKey returns the unwrapped DEK of the account. Accounts registered
+// before DEKs existed get one on first use.
+func (s *DataKeyService) GetDataKey(ctx context.Context, accountID uint32) (*encryptor.Encryptor, error) {
+	dataKey, err := s.getDataKey(ctx, accountID)
+	return dataKey.key, err
+}
+
+// ForgetDataKey drops the cached DEK, e.g. when the account is deleted.
+func (s *DataKeyService) ForgetDataKey(accountID uint32) {
+	s.mx.Lock()
+	delete(s.cache, accountID)
+	s.mx.Unlock()
+}
+
+// legacyKeys returns the keys that open record fields sealed without
+// associated data: the data key and, only for accounts that had records
+// before DEKs existed, the master key. Once every record is bound there are
+// none, unless force is set.
+func (s *DataKeyService) legacyKeys(ctx context.Context, accountID uint32, force bool) ([]*encryptor.Encryptor, error) {
+	if s.recordsBound.Load() && !force {
+		return nil, nil
+	}
+
+	dataKey, err := s.getDataKey(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if !dataKey.legacy {
+		return []*encryptor.Encryptor{dataKey.key}, nil
+	}
+	return []*encryptor.Encryptor{dataKey.key, s.master}, nil
+}
+
+// setRecordsBound stops accepting record fields without associated data.
+func (s *DataKeyService) setRecordsBound() {
+	if !s.recordsBound.Swap(true) {
+		s.logger.Info("all records are bound, fields without associated data are rejected")
+	}
+}
+
+func (s *DataKeyService) getDataKey(ctx context.Context, accountID uint32) (cachedDataKey, error) {
+	s.mx.RLock()
+	dataKey, ok := s.cache[accountID]
+	s.mx.RUnlock()
+	if ok {
+		return dataKey, nil
+	}
+
+	stored, err := s.store.GetAccountDataKey(ctx, accountID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return dataKey, err
+		}
+		return s.createDataKey(ctx, accountID, true)
+	}
+
+	return s.unwrap(&stored)
+}
+
+// createDataKey generates and stores a DEK. legacy marks accounts whose
+// records may predate it.
+func (s *DataKeyService) createDataKey(ctx context.Context, accountID uint32, legacy bool) (cachedDataKey, error) {
+	key := make([]byte, dataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return cachedDataKey{}, fmt.Errorf("data key generation error: %w", err)
+	}
+
+	wrappedKey, err := s.master.Encrypt(base64.StdEncoding.EncodeToString(key))
+	if err != nil {
+		return cachedDataKey{}, fmt.Errorf("data key wrap error: %w", err)
+	}
+
+	// a concurrent request may have stored a key first, use whichever won
+	stored, err := s.store.CreateAccountDataKey(ctx, &models.AccountDataKey{
+		AccountID:  accountID,
+		WrappedKey: wrappedKey,
+		Legacy:     legacy,
+	})
+	if err != nil {
+		return cachedDataKey{}, err
+	}
+
+	s.logger.Infof("data key created for account %d", accountID)
+
+	return s.unwrap(&stored)
+}
+
+func (s *DataKeyService) unwrap(stored *models.AccountDataKey) (cachedDataKey, error) {
+	encodedKey, err := s.master.Decrypt(stored.WrappedKey)
+	if err != nil {
+		return cachedDataKey{}, fmt.Errorf("data key unwrap error for account %d: %w", stored.AccountID, err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil || len(key) != dataKeySize {
+		return cachedDataKey{}, fmt.Errorf("invalid data key for account %d", stored.AccountID)
+	}
+
+	dataKey := cachedDataKey{key: encryptor.NewEncryptor(key), legacy: stored.Legacy}
+
+	s.mx.Lock()
+	s.cache[stored.AccountID] = dataKey
+	s.mx.Unlock()
+
+	return dataKey, nil
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+)
+
+// ExportAccount writes a zip archive with the account metadata, every record,
+// trashed record, record version and folder in decrypted form, the token
+// history and the audit log of the account to w.
+func (s *DataService) ExportAccount(ctx context.Context, w io.Writer) error {
+	accountID, err := s.getAccountIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	account, err := s.store.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	records, err := s.store.GetDataList(ctx, accountID, models.DataFilter{})
+	if err != nil {
+		return err
+	}
+
+	if records == nil {
+		records = []models.DataStoreFormat{}
+	}
+
+	for i := range records {
+		_, err = s.DecryptData(ctx, &records[i])
+		if err != nil {
+			return fmt.Errorf("decryption error for data ID %d: %v", records[i].ID, err)
+		}
+	}
+
+	trash, err := s.ListTrash(ctx)
+	if err != nil {
+		return err
+	}
+
+	if trash == nil {
+		trash = []models.TrashItem{}
+	}
+
+	history, err := s.store.GetAccountDataHistory(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	if history == nil {
+		history = []models.DataVersion{}
+	}
+
+	for i := range history {
+		_, err = s.DecryptData(ctx, &history[i].Data)
+		if err != nil {
+			return fmt.Errorf("decryption error for data ID %d version %d: %v", history[i].Data.ID, history[i].Version, err)
+		}
+	}
+
+	folders, err := s.ListFolders(ctx)
+	if err != nil {
+		return err
+	}
+
+	if folders == nil {
+		folders = []models.Folder{}
+	}
+
+	refreshTokens, err := s.store.GetAccountRefreshTokens(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	tokenHistory := make([]models.TokenHistoryItem, 0, len(refreshTokens))
+	for _, item := range refreshTokens {
+		tokenHistory = append(tokenHistory, models.TokenHistoryItem{
+			FamilyID:  item.FamilyID,
+			CreatedOn: item.CreatedOn,
+			ExpiresAt: item.ExpiresAt,
+			UsedAt:    item.UsedAt,
+			RevokedAt: item.RevokedAt,
+		})
+	}
+
+	s.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditAccountExport})
+
+	auditLog, err := s.exportAuditLog(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+
+	files := []struct {
+		name    string
+		content any
+	}{
+		{"account.json", models.AccountInfo{
+			ID:          account.ID,
+			Username:    account.Username,
+			TOTPEnabled: account.TOTPEnabled,
+			CreatedOn:   account.CreatedOn,
+		}},
+		{"records.json", records},
+		{"trash.json", trash},
+		{"history.json", history},
+		{"folders.json", folders},
+		{"token_history.json", tokenHistory},
+		{"audit_log.json", auditLog},
+	}
+
+	now := time.Now()
+	for _, file := range files {
+		fw, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(fw)
+		encoder.SetIndent("", "	")
+		err = encoder.Encode(file.content)
+		if err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// exportAuditLog reads the whole audit log of the account page by page.
+func (s *DataService) exportAuditLog(ctx context.Context, accountID uint32) ([]models.AuditEvent, error) {
+	result := []models.AuditEvent{}
+	filter := models.AuditFilter{Limit: maxAuditPageSize}
+
+	for {
+		events, err := s.store.GetAuditEvents(ctx, accountID, &filter)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, events...)
+		if len(events) < filter.Limit {
+			return result, nil
+		}
+		filter.BeforeID = events[len(events)-1].ID
+	}
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	encryptor "github.com/bbquite/go-pass-keeper/internal/encryption"
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	"github.com/bbquite/go-pass-keeper/internal/utils"
+	"go.uber.org/zap"
+)
+
+// exportStore keeps the records of one account in memory. Methods the export
+// does not use are left to the embedded interface and panic if called.
+type exportStore struct {
+	dataStorageRepo
+
+	dataKey   *models.AccountDataKey
+	records   []models.DataStoreFormat
+	deletedAt map[uint32]time.Time
+	history   []models.DataVersion
+	audit     []models.AuditEvent
+}
+
+func newExportStore() *exportStore {
+	return &exportStore{deletedAt: make(map[uint32]time.Time)}
+}
+
+func (s *exportStore) GetAccountDataKey(ctx context.Context, accountID uint32) (models.AccountDataKey, error) {
+	if s.dataKey == nil {
+		return models.AccountDataKey{}, sql.ErrNoRows
+	}
+	return *s.dataKey, nil
+}
+
+func (s *exportStore) CreateAccountDataKey(ctx context.Context, key *models.AccountDataKey) (models.AccountDataKey, error) {
+	if s.dataKey == nil {
+		s.dataKey = key
+	}
+	return *s.dataKey, nil
+}
+
+func (s *exportStore) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	event.ID = uint64(len(s.audit) + 1)
+	s.audit = append(s.audit, *event)
+	return nil
+}
+
+func (s *exportStore) GetAuditEvents(ctx context.Context, accountID uint32, filter *models.AuditFilter) ([]models.AuditEvent, error) {
+	if filter.BeforeID != 0 {
+		return nil, nil
+	}
+	return s.audit, nil
+}
+
+func (s *exportStore) GetAccountByID(ctx context.Context, accountID uint32) (models.Account, error) {
+	return models.Account{ID: accountID, Username: "user"}, nil
+}
+
+func (s *exportStore) GetAccountRefreshTokens(ctx context.Context, accountID uint32) ([]models.RefreshToken, error) {
+	return nil, nil
+}
+
+func (s *exportStore) GetFolders(ctx context.Context, accountID uint32) ([]models.Folder, error) {
+	return nil, nil
+}
+
+func (s *exportStore) CreateData(ctx context.Context, accountID uint32, data *models.DataStoreFormat) (models.DataStoreFormat, error) {
+	data.ID = uint32(len(s.records) + 1)
+	s.records = append(s.records, *data)
+	return *data, nil
+}
+
+func (s *exportStore) GetDataByIDForUser(ctx context.Context, accountID uint32, dataID uint32) (models.DataStoreFormat, error) {
+	for _, item := range s.records {
+		if _, deleted := s.deletedAt[item.ID]; item.ID == dataID && !deleted {
+			return item, nil
+		}
+	}
+	return models.DataStoreFormat{}, sql.ErrNoRows
+}
+
+func (s *exportStore) DeleteData(ctx context.Context, accountID uint32, dataID uint32) error {
+	s.deletedAt[dataID] = time.Now()
+	return nil
+}
+
+func (s *exportStore) GetDataList(ctx context.Context, accountID uint32, filter models.DataFilter) ([]models.DataStoreFormat, error) {
+	var result []models.DataStoreFormat
+	for _, item := range s.records {
+		if _, deleted := s.deletedAt[item.ID]; !deleted {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+func (s *exportStore) GetTrash(ctx context.Context, accountID uint32) ([]models.TrashItem, error) {
+	var result []models.TrashItem
+	for _, item := range s.records {
+		if deletedAt, deleted := s.deletedAt[item.ID]; deleted {
+			result = append(result, models.TrashItem{Data: item, DeletedAt: deletedAt})
+		}
+	}
+	return result, nil
+}
+
+func (s *exportStore) GetAccountDataHistory(ctx context.Context, accountID uint32) ([]models.DataVersion, error) {
+	return s.history, nil
+}
+
+func readExportFile(t *testing.T, archive []byte, name string, v any) {
+	t.Helper()
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("zip: %v", err)
+	}
+	file, err := reader.Open(name)
+	if err != nil {
+		t.Fatalf("open %s: %v", name, err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("read %s: %v", name, err)
+	}
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		t.Fatalf("parse %s: %v", name, err)
+	}
+}
+
+func TestExportAccountKeepsTrashedRecords(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	store := newExportStore()
+	master := encryptor.NewEncryptor(bytes.Repeat([]byte{7}, 32))
+	service := NewDataService(store, NewDataKeyService(store, master, logger), NewAuditService(store, logger), 5, logger)
+
+	ctx := context.WithValue(context.Background(), utils.AccountIDKey, uint32(1))
+
+	kept, err := service.CreateData(ctx, &models.DataStoreFormat{DataType: models.DataTypeTEXT, DataInfo: "kept", Meta: "kept meta"})
+	if err != nil {
+		t.Fatalf("CreateData: %v", err)
+	}
+	trashed, err := service.CreateData(ctx, &models.DataStoreFormat{DataType: models.DataTypeTEXT, DataInfo: "trashed", Meta: "trashed meta"})
+	if err != nil {
+		t.Fatalf("CreateData: %v", err)
+	}
+
+	// an earlier version of the trashed record
+	version := models.DataStoreFormat{DataType: models.DataTypeTEXT, DataInfo: "trashed v1", Meta: "trashed meta"}
+	_, err = service.EncryptData(ctx, &version)
+	if err != nil {
+		t.Fatalf("EncryptData: %v", err)
+	}
+	version.ID = trashed.ID
+	store.history = append(store.history, models.DataVersion{Version: 1, Data: version, ArchivedAt: time.Now()})
+
+	err = service.DeleteData(ctx, trashed.ID)
+	if err != nil {
+		t.Fatalf("DeleteData: %v", err)
+	}
+
+	var out bytes.Buffer
+	err = service.ExportAccount(ctx, &out)
+	if err != nil {
+		t.Fatalf("ExportAccount: %v", err)
+	}
+
+	var records []models.DataStoreFormat
+	readExportFile(t, out.Bytes(), "records.json", &records)
+	if len(records) != 1 || records[0].ID != kept.ID || records[0].DataInfo != "kept" {
+		t.Errorf("records.json = %+v, want only record %d", records, kept.ID)
+	}
+
+	var trash []models.TrashItem
+	readExportFile(t, out.Bytes(), "trash.json", &trash)
+	if len(trash) != 1 || trash[0].Data.ID != trashed.ID || trash[0].Data.DataInfo != "trashed" || trash[0].Data.Meta != "trashed meta" {
+		t.Errorf("trash.json = %+v, want decrypted record %d", trash, trashed.ID)
+	}
+
+	var history []models.DataVersion
+	readExportFile(t, out.Bytes(), "history.json", &history)
+	if len(history) != 1 || history[0].Data.ID != trashed.ID || history[0].Data.DataInfo != "trashed v1" {
+		t.Errorf("history.json = %+v, want decrypted version of record %d", history, trashed.ID)
+	}
+}
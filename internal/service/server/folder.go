@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+)
+
+var (
+	ErrInvalidFolderName = errors.New("folder name required")
+	ErrFolderCycle       = errors.New("folder cannot be moved into itself or its subfolder")
+)
+
+// folderAD is the associated data of a folder name, bound to the account like
+// record fields are.
+func folderAD(accountID uint32) []byte {
+	return []byte(fmt.Sprintf("go-pass-keeper/folder|%d|name", accountID))
+}
+
+func (s *DataService) CreateFolder(ctx context.Context, name string, parentID uint32) (models.Folder, error) {
+	if strings.TrimSpace(name) == "" {
+		return models.Folder{}, ErrInvalidFolderName
+	}
+
+	accountID, dataKey, err := s.getDataKey(ctx)
+	if err != nil {
+		return models.Folder{}, err
+	}
+
+	encryptedName, err := dataKey.EncryptWithAD(name, folderAD(accountID))
+	if err != nil {
+		return models.Folder{}, fmt.Errorf("encryption error: %v", err)
+	}
+
+	result, err := s.store.CreateFolder(ctx, accountID, &models.Folder{ParentID: parentID, Name: encryptedName})
+	if err != nil {
+		return result, err
+	}
+
+	result.Name = name
+	return result, nil
+}
+
+func (s *DataService) ListFolders(ctx context.Context) ([]models.Folder, error) {
+	accountID, dataKey, err := s.getDataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	folders, err := s.store.GetFolders(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range folders {
+		folders[i].Name, err = dataKey.DecryptWithAD(folders[i].Name, folderAD(accountID))
+		if err != nil {
+			return nil, fmt.Errorf("decryption error for folder ID %d: %v", folders[i].ID, err)
+		}
+	}
+
+	return folders, nil
+}
+
+func (s *DataService) RenameFolder(ctx context.Context, folderID uint32, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrInvalidFolderName
+	}
+
+	accountID, dataKey, err := s.getDataKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	encryptedName, err := dataKey.EncryptWithAD(name, folderAD(accountID))
+	if err != nil {
+		return fmt.Errorf("encryption error: %v", err)
+	}
+
+	return s.store.RenameFolder(ctx, accountID, folderID, encryptedName)
+}
+
+// MoveFolder puts the folder under parentID, zero for the top level.
+func (s *DataService) MoveFolder(ctx context.Context, folderID uint32, parentID uint32) error {
+	accountID, err := s.getAccountIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	moved, err := s.store.MoveFolder(ctx, accountID, folderID, parentID)
+	if err != nil {
+		return err
+	}
+	if !moved {
+		return ErrFolderCycle
+	}
+
+	return nil
+}
+
+// DeleteFolder removes the folder. Its records and subfolders move up to its
+// parent, so no record is deleted with it.
+func (s *DataService) DeleteFolder(ctx context.Context, folderID uint32) error {
+	accountID, err := s.getAccountIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.store.DeleteFolder(ctx, accountID, folderID)
+}
+
+// MoveData puts the record into folderID, zero for the top level.
+func (s *DataService) MoveData(ctx context.Context, dataID uint32, folderID uint32) error {
+	accountID, err := s.getAccountIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.MoveData(ctx, accountID, dataID, folderID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditDataMove, RecordID: dataID, Details: fmt.Sprintf("folder %d", folderID)})
+
+	return nil
+}
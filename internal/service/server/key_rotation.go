@@ -0,0 +1,378 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	encryptor "github.com/bbquite/go-pass-keeper/internal/encryption"
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// keyRotationJobVersion is bumped when the job learns to fix more, so runs
+	// finished by an older version are repeated. Version 2 binds records with
+	// associated data, version 3 also walks record history.
+	keyRotationJobVersion = 3
+
+	defReencryptBatchSize = 100
+	maxReencryptBatchSize = 10000
+)
+
+var ErrReencryptionRunning = errors.New("re-encryption already running")
+
+var keyRotationStages = []string{
+	models.KeyRotationDataKeys,
+	models.KeyRotationTOTPSecrets,
+	models.KeyRotationRecords,
+	models.KeyRotationHistory,
+}
+
+type keyRotationStorageRepo interface {
+	GetKeyRotation(ctx context.Context, keyID string) (models.KeyRotation, error)
+	SaveKeyRotation(ctx context.Context, rotation *models.KeyRotation) error
+	HasCleanKeyRotation(ctx context.Context, jobVersion int) (bool, error)
+	CountKeyRotationRows(ctx context.Context, stage string) (int64, error)
+
+	GetDataKeysAfter(ctx context.Context, afterAccountID uint32, limit int) ([]models.AccountDataKey, error)
+	ReplaceAccountDataKey(ctx context.Context, accountID uint32, oldWrappedKey string, wrappedKey string) error
+	GetTOTPSecretsAfter(ctx context.Context, afterAccountID uint32, limit int) ([]models.AccountTOTP, error)
+	ReplaceTOTPSecret(ctx context.Context, accountID uint32, oldSecret string, secret string) error
+	GetDataAfter(ctx context.Context, afterID uint32, limit int) ([]models.StoredRecord, error)
+	ReplaceDataCiphertext(ctx context.Context, old *models.DataStoreFormat, data *models.DataStoreFormat) error
+	GetDataHistoryAfter(ctx context.Context, afterID uint32, limit int) ([]models.StoredRecord, error)
+	ReplaceHistoryCiphertext(ctx context.Context, old *models.DataStoreFormat, data *models.DataStoreFormat) error
+}
+
+// batchResult is the outcome of one re-encryption batch. A batch with no rows
+// ends its stage.
+type batchResult struct {
+	cursor    uint32
+	processed int64
+	updated   int64
+	failed    int64
+}
+
+// KeyRotationService moves everything encrypted with the master key to the
+// active key version: wrapped data keys, TOTP secrets, and records and record
+// versions written before per-account data keys, which are re-encrypted with
+// their data key.
+// Progress is saved after every batch, so an interrupted run resumes where it
+// stopped.
+type KeyRotationService struct {
+	store    keyRotationStorageRepo
+	master   *encryptor.Encryptor
+	dataKeys *DataKeyService
+	running  sync.Mutex
+	logger   *zap.SugaredLogger
+}
+
+func NewKeyRotationService(store keyRotationStorageRepo, master *encryptor.Encryptor, dataKeys *DataKeyService, logger *zap.SugaredLogger) *KeyRotationService {
+	return &KeyRotationService{
+		store:    store,
+		master:   master,
+		dataKeys: dataKeys,
+		logger:   logger.Named("KEY ROTATION"),
+	}
+}
+
+// LoadStatus checks whether a run of the current job version already finished
+// without failures. From then on every record is bound with associated data
+// and the data keys reject fields without it.
+func (s *KeyRotationService) LoadStatus(ctx context.Context) error {
+	clean, err := s.store.HasCleanKeyRotation(ctx, keyRotationJobVersion)
+	if err != nil {
+		return err
+	}
+	if clean {
+		s.dataKeys.setRecordsBound()
+	}
+	return nil
+}
+
+// Reencrypt runs or resumes re-encryption to the active key and calls report
+// after every batch. A finished run is only repeated with restart or when it
+// was made by an older job version.
+func (s *KeyRotationService) Reencrypt(ctx context.Context, batchSize int, restart bool, report func(models.KeyRotation) error) error {
+	if !s.running.TryLock() {
+		return ErrReencryptionRunning
+	}
+	defer s.running.Unlock()
+
+	if batchSize <= 0 {
+		batchSize = defReencryptBatchSize
+	}
+	batchSize = min(batchSize, maxReencryptBatchSize)
+
+	rotation, err := s.loadRotation(ctx, restart)
+	if err != nil {
+		return err
+	}
+
+	for _, stage := range keyRotationStages {
+		if rotation.FinishedAt != nil {
+			break
+		}
+		if stageIndex(rotation.Stage) > stageIndex(stage) {
+			continue
+		}
+
+		rotation.Total, err = s.store.CountKeyRotationRows(ctx, stage)
+		if err != nil {
+			return err
+		}
+
+		for {
+			err = report(rotation)
+			if err != nil {
+				return err
+			}
+
+			result, err := s.runBatch(ctx, stage, rotation.Cursor, batchSize)
+			if err != nil {
+				return err
+			}
+			if result.processed == 0 {
+				break
+			}
+
+			rotation.Cursor = result.cursor
+			rotation.Processed += result.processed
+			rotation.Updated += result.updated
+			rotation.Failed += result.failed
+
+			err = s.store.SaveKeyRotation(ctx, &rotation)
+			if err != nil {
+				return err
+			}
+		}
+
+		rotation.Stage = nextStage(stage)
+		rotation.Cursor = 0
+		rotation.Processed = 0
+		rotation.Total = 0
+		if rotation.Stage == models.KeyRotationDone {
+			now := time.Now()
+			rotation.FinishedAt = &now
+		}
+
+		err = s.store.SaveKeyRotation(ctx, &rotation)
+		if err != nil {
+			return err
+		}
+		s.logger.Infof("key %s: stage %s finished, %d updated, %d failed", rotation.KeyID, stage, rotation.Updated, rotation.Failed)
+		if rotation.FinishedAt != nil && rotation.Failed == 0 {
+			s.dataKeys.setRecordsBound()
+		}
+	}
+
+	return report(rotation)
+}
+
+func (s *KeyRotationService) loadRotation(ctx context.Context, restart bool) (models.KeyRotation, error) {
+	keyID := s.master.ActiveKeyID()
+
+	rotation, err := s.store.GetKeyRotation(ctx, keyID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return rotation, err
+	}
+
+	if err != nil || restart || rotation.JobVersion < keyRotationJobVersion {
+		rotation = models.KeyRotation{
+			KeyID:      keyID,
+			JobVersion: keyRotationJobVersion,
+			Stage:      keyRotationStages[0],
+			StartedAt:  time.Now(),
+		}
+		err = s.store.SaveKeyRotation(ctx, &rotation)
+		if err != nil {
+			return rotation, err
+		}
+	}
+
+	return rotation, nil
+}
+
+func (s *KeyRotationService) runBatch(ctx context.Context, stage string, cursor uint32, batchSize int) (batchResult, error) {
+	switch stage {
+	case models.KeyRotationDataKeys:
+		return s.reencryptDataKeys(ctx, cursor, batchSize)
+	case models.KeyRotationTOTPSecrets:
+		return s.reencryptTOTPSecrets(ctx, cursor, batchSize)
+	case models.KeyRotationHistory:
+		return s.reencryptRecords(ctx, cursor, batchSize, s.store.GetDataHistoryAfter, s.store.ReplaceHistoryCiphertext)
+	default:
+		return s.reencryptRecords(ctx, cursor, batchSize, s.store.GetDataAfter, s.store.ReplaceDataCiphertext)
+	}
+}
+
+func (s *KeyRotationService) reencryptDataKeys(ctx context.Context, cursor uint32, batchSize int) (batchResult, error) {
+	result := batchResult{cursor: cursor}
+
+	items, err := s.store.GetDataKeysAfter(ctx, cursor, batchSize)
+	if err != nil {
+		return result, err
+	}
+
+	for _, item := range items {
+		result.cursor = item.AccountID
+		result.processed++
+
+		wrappedKey, ok := s.rewrap(item.WrappedKey)
+		if !ok {
+			s.logger.Errorf("data key of account %d cannot be decrypted", item.AccountID)
+			result.failed++
+			continue
+		}
+		if wrappedKey == item.WrappedKey {
+			continue
+		}
+
+		err = s.store.ReplaceAccountDataKey(ctx, item.AccountID, item.WrappedKey, wrappedKey)
+		if err != nil {
+			return result, err
+		}
+		result.updated++
+	}
+
+	return result, nil
+}
+
+func (s *KeyRotationService) reencryptTOTPSecrets(ctx context.Context, cursor uint32, batchSize int) (batchResult, error) {
+	result := batchResult{cursor: cursor}
+
+	items, err := s.store.GetTOTPSecretsAfter(ctx, cursor, batchSize)
+	if err != nil {
+		return result, err
+	}
+
+	for _, item := range items {
+		result.cursor = item.AccountID
+		result.processed++
+
+		secret, ok := s.rewrap(item.Secret)
+		if !ok {
+			s.logger.Errorf("TOTP secret of account %d cannot be decrypted", item.AccountID)
+			result.failed++
+			continue
+		}
+		if secret == item.Secret {
+			continue
+		}
+
+		err = s.store.ReplaceTOTPSecret(ctx, item.AccountID, item.Secret, secret)
+		if err != nil {
+			return result, err
+		}
+		result.updated++
+	}
+
+	return result, nil
+}
+
+// reencryptRecords moves records still encrypted with the master key to the
+// data key of their account and re-seals records not yet bound to their
+// account, data type and field with associated data. fetch and replace read
+// and write current records or their history.
+func (s *KeyRotationService) reencryptRecords(ctx context.Context, cursor uint32, batchSize int,
+	fetch func(ctx context.Context, afterID uint32, limit int) ([]models.StoredRecord, error),
+	replace func(ctx context.Context, old *models.DataStoreFormat, data *models.DataStoreFormat) error) (batchResult, error) {
+	result := batchResult{cursor: cursor}
+
+	items, err := fetch(ctx, cursor, batchSize)
+	if err != nil {
+		return result, err
+	}
+
+	for _, item := range items {
+		result.cursor = item.Record.ID
+		result.processed++
+
+		dataKey, err := s.dataKeys.GetDataKey(ctx, item.AccountID)
+		if err != nil {
+			return result, err
+		}
+		legacy, err := s.dataKeys.legacyKeys(ctx, item.AccountID, true)
+		if err != nil {
+			return result, err
+		}
+
+		record := item.Record
+		infoAD := recordAD(item.AccountID, record.DataType, recordFieldInfo)
+		metaAD := recordAD(item.AccountID, record.DataType, recordFieldMeta)
+
+		var infoBound, metaBound bool
+		record.DataInfo, infoBound, err = openRecordField(dataKey, legacy, item.Record.DataInfo, infoAD)
+		if err == nil {
+			record.Meta, metaBound, err = openRecordField(dataKey, legacy, item.Record.Meta, metaAD)
+		}
+		if err != nil {
+			s.logger.Errorf("record %d cannot be decrypted: %v", item.Record.ID, err)
+			result.failed++
+			continue
+		}
+		if infoBound && metaBound {
+			continue
+		}
+
+		record.DataInfo, err = dataKey.EncryptWithAD(record.DataInfo, infoAD)
+		if err != nil {
+			return result, err
+		}
+		record.Meta, err = dataKey.EncryptWithAD(record.Meta, metaAD)
+		if err != nil {
+			return result, err
+		}
+
+		err = replace(ctx, &item.Record, &record)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return result, fmt.Errorf("record %d changed during re-encryption, run the job again: %w", item.Record.ID, err)
+			}
+			return result, err
+		}
+		result.updated++
+	}
+
+	return result, nil
+}
+
+// rewrap re-encrypts a master key value with the active key. Values already
+// under the active key are returned unchanged.
+func (s *KeyRotationService) rewrap(ciphertext string) (string, bool) {
+	if s.master.IsActive(ciphertext) {
+		return ciphertext, true
+	}
+
+	plaintext, err := s.master.Decrypt(ciphertext)
+	if err != nil {
+		return "", false
+	}
+
+	result, err := s.master.Encrypt(plaintext)
+	if err != nil {
+		return "", false
+	}
+	return result, true
+}
+
+func stageIndex(stage string) int {
+	for i, item := range keyRotationStages {
+		if item == stage {
+			return i
+		}
+	}
+	return len(keyRotationStages)
+}
+
+func nextStage(stage string) string {
+	i := stageIndex(stage) + 1
+	if i >= len(keyRotationStages) {
+		return models.KeyRotationDone
+	}
+	return keyRotationStages[i]
+}
@@ -0,0 +1,233 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	loginKeyUsername = "username"
+	loginKeyIP       = "ip"
+)
+
+var ErrLoginLocked = errors.New("too many failed login attempts")
+
+// LoginLockedError is returned while a username or peer address is locked out.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("%v, retry after %s", ErrLoginLocked, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginLockedError) Is(target error) bool {
+	return target == ErrLoginLocked
+}
+
+type LoginLimiterPolicy struct {
+	MaxAttempts   int
+	IPMaxAttempts int
+	BaseDelay     time.Duration
+	Lockout       time.Duration
+	Window        time.Duration
+}
+
+type loginLimiterStorageRepo interface {
+	UpdateLoginAttempt(ctx context.Context, keyType string, key string, count func(attempt *models.LoginAttempt) bool) error
+	GetLoginFailures(ctx context.Context, keyType string, key string) (int, error)
+	SetLoginLock(ctx context.Context, keyType string, key string, delay time.Duration) error
+	ReleaseLoginAttempt(ctx context.Context, keyType string, key string) error
+	ResetLoginAttempts(ctx context.Context, keyType string, key string) (bool, error)
+}
+
+// LoginLimiter tracks failed logins per username and per peer IP. Every failure
+// delays the next attempt exponentially (BaseDelay, 2*BaseDelay, ...) and after
+// MaxAttempts failures the key is locked for Lockout. An attempt is counted as
+// a failure by Check, before the password or code is verified, so parallel
+// attempts cannot get past the limit; RegisterSuccess and Release uncount it.
+type LoginLimiter struct {
+	store  loginLimiterStorageRepo
+	policy LoginLimiterPolicy
+	logger *zap.SugaredLogger
+}
+
+func NewLoginLimiter(store loginLimiterStorageRepo, policy LoginLimiterPolicy, logger *zap.SugaredLogger) *LoginLimiter {
+	return &LoginLimiter{
+		store:  store,
+		policy: policy,
+		logger: logger.Named("LIMITER"),
+	}
+}
+
+// Check counts the attempt for the username and the IP. It returns a
+// *LoginLockedError, counting nothing, when either key is locked or its
+// attempts are taken by attempts still being verified.
+func (l *LoginLimiter) Check(ctx context.Context, username string, ip string) error {
+	keys := l.keys(username, ip)
+	var counted []string
+
+	for _, keyType := range []string{loginKeyUsername, loginKeyIP} {
+		key, ok := keys[keyType]
+		if !ok {
+			continue
+		}
+
+		retryAfter, err := l.count(ctx, keyType, key)
+		if err == nil && retryAfter == 0 {
+			counted = append(counted, keyType)
+			continue
+		}
+
+		for _, countedType := range counted {
+			releaseErr := l.store.ReleaseLoginAttempt(ctx, countedType, keys[countedType])
+			if releaseErr != nil {
+				l.logger.Errorf("release login attempt error: %v", releaseErr)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		return &LoginLockedError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// count counts an attempt for the key unless it is locked or used up, and
+// returns how long to wait in that case.
+func (l *LoginLimiter) count(ctx context.Context, keyType string, key string) (time.Duration, error) {
+	maxAttempts := l.maxAttempts(keyType)
+
+	var retryAfter time.Duration
+	err := l.store.UpdateLoginAttempt(ctx, keyType, key, func(attempt *models.LoginAttempt) bool {
+		switch {
+		case attempt.LockedFor > 0:
+			retryAfter = attempt.LockedFor
+			return false
+		case attempt.SinceLast > l.policy.Window:
+			attempt.Failures = 0
+		case attempt.Failures >= maxAttempts && (attempt.LockedAfterLast || attempt.SinceLast > l.policy.Lockout):
+			// the lockout is over, one more attempt is allowed
+			attempt.Failures = maxAttempts - 1
+		case attempt.Failures >= maxAttempts:
+			// the last attempts are still being verified
+			retryAfter = max(l.policy.BaseDelay, time.Second)
+			return false
+		}
+		attempt.Failures++
+		return true
+	})
+	return retryAfter, err
+}
+
+// RegisterFailure locks the username and the IP according to the attempts
+// counted so far, including the failed one.
+func (l *LoginLimiter) RegisterFailure(ctx context.Context, username string, ip string) error {
+	for keyType, key := range l.keys(username, ip) {
+		failures, err := l.store.GetLoginFailures(ctx, keyType, key)
+		if err != nil {
+			return err
+		}
+
+		maxAttempts := l.maxAttempts(keyType)
+
+		delay := l.delay(failures, maxAttempts)
+		if failures >= maxAttempts {
+			l.logger.Warnf("login locked for %s %s after %d failures", keyType, key, failures)
+		}
+
+		err = l.store.SetLoginLock(ctx, keyType, key, delay)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RegisterSuccess clears the failures of the username and uncounts the
+// attempt of the IP.
+func (l *LoginLimiter) RegisterSuccess(ctx context.Context, username string, ip string) error {
+	_, err := l.store.ResetLoginAttempts(ctx, loginKeyUsername, normalizeUsername(username))
+	if err != nil {
+		return err
+	}
+
+	if ip == "" {
+		return nil
+	}
+	return l.store.ReleaseLoginAttempt(ctx, loginKeyIP, ip)
+}
+
+// Release uncounts the attempt of the username and the IP, for a verified
+// password or code that is not a login.
+func (l *LoginLimiter) Release(ctx context.Context, username string, ip string) error {
+	for keyType, key := range l.keys(username, ip) {
+		err := l.store.ReleaseLoginAttempt(ctx, keyType, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Unlock clears failures and lockouts for the username and/or IP.
+// It reports whether anything was unlocked.
+func (l *LoginLimiter) Unlock(ctx context.Context, username string, ip string) (bool, error) {
+	var unlocked bool
+
+	for keyType, key := range l.keys(username, ip) {
+		ok, err := l.store.ResetLoginAttempts(ctx, keyType, key)
+		if err != nil {
+			return unlocked, err
+		}
+		unlocked = unlocked || ok
+	}
+
+	return unlocked, nil
+}
+
+func (l *LoginLimiter) delay(failures int, maxAttempts int) time.Duration {
+	if failures >= maxAttempts {
+		return l.policy.Lockout
+	}
+
+	delay := l.policy.BaseDelay
+	for i := 1; i < failures && delay < l.policy.Lockout; i++ {
+		delay *= 2
+	}
+	if delay > l.policy.Lockout {
+		delay = l.policy.Lockout
+	}
+	return delay
+}
+
+func (l *LoginLimiter) maxAttempts(keyType string) int {
+	if keyType == loginKeyIP {
+		return l.policy.IPMaxAttempts
+	}
+	return l.policy.MaxAttempts
+}
+
+func (l *LoginLimiter) keys(username string, ip string) map[string]string {
+	keys := make(map[string]string, 2)
+	if username != "" {
+		keys[loginKeyUsername] = normalizeUsername(username)
+	}
+	if ip != "" {
+		keys[loginKeyIP] = ip
+	}
+	return keys
+}
+
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	"go.uber.org/zap"
+)
+
+type memoryAttempt struct {
+	failures    int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+// memoryLimiterStore keeps attempt counters in memory. The mutex stands for
+// the row lock of the database store.
+type memoryLimiterStore struct {
+	mx       sync.Mutex
+	attempts map[string]*memoryAttempt
+}
+
+func newMemoryLimiterStore() *memoryLimiterStore {
+	return &memoryLimiterStore{attempts: make(map[string]*memoryAttempt)}
+}
+
+func (s *memoryLimiterStore) attempt(keyType string, key string) *memoryAttempt {
+	attempt, ok := s.attempts[keyType+"/"+key]
+	if !ok {
+		attempt = &memoryAttempt{lastFailure: time.Now()}
+		s.attempts[keyType+"/"+key] = attempt
+	}
+	return attempt
+}
+
+func (s *memoryLimiterStore) UpdateLoginAttempt(_ context.Context, keyType string, key string, count func(attempt *models.LoginAttempt) bool) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	stored := s.attempt(keyType, key)
+	attempt := models.LoginAttempt{
+		Failures:        stored.failures,
+		SinceLast:       time.Since(stored.lastFailure),
+		LockedFor:       max(time.Until(stored.lockedUntil), 0),
+		LockedAfterLast: !stored.lockedUntil.Before(stored.lastFailure),
+	}
+	if !count(&attempt) {
+		return nil
+	}
+
+	stored.failures = attempt.Failures
+	stored.lastFailure = time.Now()
+	return nil
+}
+
+func (s *memoryLimiterStore) GetLoginFailures(_ context.Context, keyType string, key string) (int, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.attempt(keyType, key).failures, nil
+}
+
+func (s *memoryLimiterStore) SetLoginLock(_ context.Context, keyType string, key string, delay time.Duration) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.attempt(keyType, key).lockedUntil = time.Now().Add(delay)
+	return nil
+}
+
+func (s *memoryLimiterStore) ReleaseLoginAttempt(_ context.Context, keyType string, key string) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	attempt := s.attempt(keyType, key)
+	attempt.failures = max(attempt.failures-1, 0)
+	return nil
+}
+
+func (s *memoryLimiterStore) ResetLoginAttempts(_ context.Context, keyType string, key string) (bool, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	_, ok := s.attempts[keyType+"/"+key]
+	delete(s.attempts, keyType+"/"+key)
+	return ok, nil
+}
+
+func newTestLimiter(store loginLimiterStorageRepo) *LoginLimiter {
+	return NewLoginLimiter(store, LoginLimiterPolicy{
+		MaxAttempts:   3,
+		IPMaxAttempts: 100,
+		BaseDelay:     time.Millisecond,
+		Lockout:       time.Minute,
+		Window:        time.Hour,
+	}, zap.NewNop().Sugar())
+}
+
+func TestLoginLimiterConcurrentAttempts(t *testing.T) {
+	ctx := context.Background()
+	limiter := newTestLimiter(newMemoryLimiterStore())
+
+	// every attempt is checked before any of them fails
+	var allowed atomic.Int32
+	start := make(chan struct{})
+	verified := make(chan struct{})
+	var checked sync.WaitGroup
+	var done sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		checked.Add(1)
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			<-start
+			err := limiter.Check(ctx, "user", "10.0.0.1")
+			checked.Done()
+			if err != nil {
+				if !errors.Is(err, ErrLoginLocked) {
+					t.Errorf("check error: %v", err)
+				}
+				return
+			}
+			allowed.Add(1)
+			<-verified
+			if err := limiter.RegisterFailure(ctx, "user", "10.0.0.1"); err != nil {
+				t.Errorf("register failure error: %v", err)
+			}
+		}()
+	}
+	close(start)
+	checked.Wait()
+	close(verified)
+	done.Wait()
+
+	if got := allowed.Load(); got != 3 {
+		t.Fatalf("allowed %d parallel attempts, want 3", got)
+	}
+
+	err := limiter.Check(ctx, "user", "10.0.0.2")
+	var lockedErr *LoginLockedError
+	if !errors.As(err, &lockedErr) || lockedErr.RetryAfter <= 59*time.Second {
+		t.Fatalf("check after the limit: %v, want the lockout", err)
+	}
+}
+
+func TestLoginLimiterSuccessUncountsAttempt(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryLimiterStore()
+	limiter := newTestLimiter(store)
+
+	for i := 0; i < 10; i++ {
+		if err := limiter.Check(ctx, "user", "10.0.0.1"); err != nil {
+			t.Fatalf("check %d: %v", i, err)
+		}
+		if err := limiter.Release(ctx, "user", "10.0.0.1"); err != nil {
+			t.Fatalf("release %d: %v", i, err)
+		}
+	}
+
+	if err := limiter.Check(ctx, "user", "10.0.0.1"); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if err := limiter.RegisterSuccess(ctx, "user", "10.0.0.1"); err != nil {
+		t.Fatalf("register success: %v", err)
+	}
+
+	if failures, _ := store.GetLoginFailures(ctx, loginKeyUsername, "user"); failures != 0 {
+		t.Fatalf("username failures %d after success, want 0", failures)
+	}
+	if failures, _ := store.GetLoginFailures(ctx, loginKeyIP, "10.0.0.1"); failures != 0 {
+		t.Fatalf("ip failures %d after success, want 0", failures)
+	}
+}
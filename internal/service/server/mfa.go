@@ -0,0 +1,295 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	"github.com/bbquite/go-pass-keeper/internal/utils"
+	jwttoken "github.com/bbquite/go-pass-keeper/pkg/jwt_token"
+	"github.com/bbquite/go-pass-keeper/pkg/totp"
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	totpIssuer         = "GoPassKeeper"
+	recoveryCodesCount = 10
+	recoveryCodeSize   = 10
+)
+
+var (
+	ErrMfaAlreadyEnabled = errors.New("two-factor authentication already enabled")
+	ErrMfaNotEnrolled    = errors.New("two-factor authentication not enrolled")
+	ErrInvalidMfaToken   = errors.New("invalid or expired mfa token")
+	ErrIncorrectMfaCode  = errors.New("incorrect authentication code")
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// CompleteMfaLogin finishes a two-step login started by AuthUser and starts a
+// session for deviceName. The mfa token is single use once a valid code has
+// been presented.
+func (service *AuthService) CompleteMfaLogin(ctx context.Context, mfaToken string, code string, deviceName string) (jwttoken.JWT, error) {
+	var token jwttoken.JWT
+
+	claims, err := service.jwtManager.ExtractClaimsFromToken(mfaToken)
+	if err != nil || !claims.MfaPending || claims.ID == "" || claims.ExpiresAt == nil {
+		return token, ErrInvalidMfaToken
+	}
+
+	revoked, err := service.revocation.IsTokenRevoked(ctx, claims.ID)
+	if err != nil {
+		return token, err
+	}
+	if revoked {
+		return token, ErrInvalidMfaToken
+	}
+
+	account, err := service.store.GetAccountByID(ctx, claims.UserID)
+	if err != nil {
+		return token, err
+	}
+
+	ip := utils.GetPeerIP(ctx)
+
+	err = service.limiter.Check(ctx, account.Username, ip)
+	if err != nil {
+		return token, err
+	}
+
+	err = service.verifySecondFactor(ctx, claims.UserID, code)
+	if err != nil {
+		if errors.Is(err, ErrIncorrectMfaCode) {
+			service.registerLoginFailure(ctx, account.Username, ip)
+			service.audit.Record(ctx, claims.UserID, models.AuditEvent{EventType: models.AuditLoginFailed, Details: "second factor"})
+		}
+		return token, err
+	}
+
+	service.registerLoginSuccess(ctx, account.Username, ip)
+
+	err = service.revocation.RevokeToken(ctx, claims.ID, claims.UserID, claims.ExpiresAt.Time)
+	if err != nil {
+		return token, err
+	}
+
+	session, err := service.startSession(ctx, claims.UserID, deviceName)
+	if err != nil {
+		return token, err
+	}
+
+	service.audit.Record(ctx, claims.UserID, models.AuditEvent{EventType: models.AuditLogin, SessionID: session.ID, Details: "second factor"})
+
+	return service.issueTokenPair(ctx, session)
+}
+
+// EnrollTotp generates a new pending secret for the account. Two-factor
+// authentication is enabled only after ConfirmTotp succeeds.
+func (service *AuthService) EnrollTotp(ctx context.Context) (models.TOTPEnrollment, error) {
+	var enrollment models.TOTPEnrollment
+
+	accountID, err := service.getAccountIDFromContext(ctx)
+	if err != nil {
+		return enrollment, err
+	}
+
+	account, err := service.store.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return enrollment, err
+	}
+	if account.TOTPEnabled {
+		return enrollment, ErrMfaAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return enrollment, err
+	}
+
+	encryptedSecret, err := service.encryptor.Encrypt(secret)
+	if err != nil {
+		return enrollment, err
+	}
+
+	err = service.store.SetAccountTOTPSecret(ctx, accountID, encryptedSecret)
+	if err != nil {
+		return enrollment, err
+	}
+
+	uri := totp.KeyURI(totpIssuer, account.Username, secret)
+
+	qr, err := qrcode.New(uri, qrcode.Medium)
+	if err != nil {
+		return enrollment, err
+	}
+
+	enrollment.Secret = secret
+	enrollment.URI = uri
+	enrollment.QRCode = qr.ToSmallString(false)
+
+	return enrollment, nil
+}
+
+// ConfirmTotp enables two-factor authentication once the user proves the
+// authenticator app is set up, and returns fresh one-time recovery codes.
+func (service *AuthService) ConfirmTotp(ctx context.Context, code string) ([]string, error) {
+	accountID, err := service.getAccountIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accountTOTP, err := service.store.GetAccountTOTP(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if accountTOTP.Enabled {
+		return nil, ErrMfaAlreadyEnabled
+	}
+	if accountTOTP.Secret == "" {
+		return nil, ErrMfaNotEnrolled
+	}
+
+	err = service.verifyTOTPCode(ctx, accountTOTP, code)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes := make([]string, 0, recoveryCodesCount)
+	recoveryCodeHashes := make([]string, 0, recoveryCodesCount)
+	for i := 0; i < recoveryCodesCount; i++ {
+		recoveryCode, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		recoveryCodes = append(recoveryCodes, recoveryCode)
+		recoveryCodeHashes = append(recoveryCodeHashes, utils.GenerateSHAString(normalizeRecoveryCode(recoveryCode)))
+	}
+
+	err = service.store.EnableAccountTOTP(ctx, accountID, recoveryCodeHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	service.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditMfaEnable})
+
+	return recoveryCodes, nil
+}
+
+// DisableTotp turns two-factor authentication off. Both the account password
+// and a current code (or a recovery code) are required.
+func (service *AuthService) DisableTotp(ctx context.Context, password string, code string) error {
+	accountID, err := service.getAccountIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	account, err := service.store.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	ip := utils.GetPeerIP(ctx)
+
+	err = service.limiter.Check(ctx, account.Username, ip)
+	if err != nil {
+		return err
+	}
+
+	_, err = service.checkAccountPassword(ctx, account.Username, password)
+	if err != nil {
+		if errors.Is(err, ErrIncorrectLoginData) {
+			service.registerLoginFailure(ctx, account.Username, ip)
+			service.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditLoginFailed, Details: "disable mfa"})
+		}
+		return err
+	}
+
+	err = service.verifySecondFactor(ctx, accountID, code)
+	if err != nil {
+		if errors.Is(err, ErrIncorrectMfaCode) {
+			service.registerLoginFailure(ctx, account.Username, ip)
+			service.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditLoginFailed, Details: "disable mfa second factor"})
+		}
+		return err
+	}
+	service.releaseLoginAttempt(ctx, account.Username, ip)
+
+	err = service.store.DisableAccountTOTP(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	service.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditMfaDisable})
+	return nil
+}
+
+// verifySecondFactor accepts either a current TOTP code or an unused recovery code.
+func (service *AuthService) verifySecondFactor(ctx context.Context, accountID uint32, code string) error {
+	accountTOTP, err := service.store.GetAccountTOTP(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if !accountTOTP.Enabled {
+		return ErrMfaNotEnrolled
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		return service.verifyTOTPCode(ctx, accountTOTP, code)
+	}
+
+	used, err := service.store.UseRecoveryCode(ctx, accountID, utils.GenerateSHAString(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrIncorrectMfaCode
+	}
+
+	service.logger.Infof("recovery code used for account %d", accountID)
+	return nil
+}
+
+func (service *AuthService) verifyTOTPCode(ctx context.Context, accountTOTP models.AccountTOTP, code string) error {
+	secret, err := service.encryptor.Decrypt(accountTOTP.Secret)
+	if err != nil {
+		return err
+	}
+
+	step, ok, err := totp.Validate(secret, code, time.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrIncorrectMfaCode
+	}
+
+	fresh, err := service.store.UpdateTOTPLastStep(ctx, accountTOTP.AccountID, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrIncorrectMfaCode
+	}
+
+	return nil
+}
+
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, recoveryCodeSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf))[:recoveryCodeSize]
+	return code[:recoveryCodeSize/2] + "-" + code[recoveryCodeSize/2:], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ReplaceAll(code, "-", "")
+	code = strings.ReplaceAll(code, " ", "")
+	return strings.ToLower(code)
+}
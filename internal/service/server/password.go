@@ -0,0 +1,183 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bbquite/go-pass-keeper/internal/utils"
+	"golang.org/x/crypto/argon2"
+)
+
+var ErrInvalidPasswordHash = errors.New("invalid password hash format")
+
+// PasswordHasher hashes account passwords and verifies them against stored hashes.
+// Verify reports needsRehash when the stored hash was produced by an outdated
+// algorithm or with different cost parameters and should be replaced.
+// DummyHash is a hash of no account's password, verified against when the
+// username is unknown so that it takes as long as a wrong password.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password string, encodedHash string) (ok bool, needsRehash bool, err error)
+	DummyHash() string
+}
+
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Argon2idHasher stores passwords as PHC strings:
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<hash>
+// Legacy unsalted SHA-512 hex hashes are still accepted and flagged for rehash.
+type Argon2idHasher struct {
+	params    Argon2Params
+	dummyHash string
+}
+
+// NewArgon2idHasher returns a hasher with params; zero salt and key lengths
+// take the defaults. Costs argon2 cannot run with are rejected.
+func NewArgon2idHasher(params Argon2Params) (*Argon2idHasher, error) {
+	if params.SaltLength == 0 {
+		params.SaltLength = DefaultArgon2Params.SaltLength
+	}
+	if params.KeyLength == 0 {
+		params.KeyLength = DefaultArgon2Params.KeyLength
+	}
+
+	err := params.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	hasher := &Argon2idHasher{params: params}
+
+	dummyPassword := make([]byte, 32)
+	if _, err := rand.Read(dummyPassword); err != nil {
+		return nil, fmt.Errorf("dummy password generation error: %w", err)
+	}
+	hasher.dummyHash, err = hasher.Hash(base64.RawStdEncoding.EncodeToString(dummyPassword))
+	if err != nil {
+		return nil, err
+	}
+
+	return hasher, nil
+}
+
+// validate checks the limits of argon2.IDKey, which panics below them.
+func (p Argon2Params) validate() error {
+	switch {
+	case p.Parallelism < 1:
+		return fmt.Errorf("argon2 parallelism must be at least 1")
+	case p.Iterations < 1:
+		return fmt.Errorf("argon2 iterations must be at least 1")
+	case p.Memory < 8*uint32(p.Parallelism):
+		return fmt.Errorf("argon2 memory must be at least %d KiB for parallelism %d", 8*uint32(p.Parallelism), p.Parallelism)
+	case p.KeyLength < 1:
+		return fmt.Errorf("argon2 key length must be at least 1")
+	}
+	return nil
+}
+
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("salt generation error: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.params.Memory, h.params.Iterations, h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *Argon2idHasher) Verify(password string, encodedHash string) (bool, bool, error) {
+	if !strings.HasPrefix(encodedHash, "$argon2id$") {
+		return verifyLegacySHA(password, encodedHash), true, nil
+	}
+
+	params, salt, key, err := decodeArgon2idHash(encodedHash)
+	if err != nil {
+		return false, false, err
+	}
+
+	inputKey := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	if subtle.ConstantTimeCompare(key, inputKey) != 1 {
+		return false, false, nil
+	}
+
+	needsRehash := params.Memory != h.params.Memory ||
+		params.Iterations != h.params.Iterations ||
+		params.Parallelism != h.params.Parallelism ||
+		params.SaltLength != h.params.SaltLength ||
+		params.KeyLength != h.params.KeyLength
+
+	return true, needsRehash, nil
+}
+
+func (h *Argon2idHasher) DummyHash() string {
+	return h.dummyHash
+}
+
+func verifyLegacySHA(password string, encodedHash string) bool {
+	inputHash := utils.GenerateSHAString(password)
+	return subtle.ConstantTimeCompare([]byte(inputHash), []byte(encodedHash)) == 1
+}
+
+func decodeArgon2idHash(encodedHash string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2 version: %d", version)
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	if params.validate() != nil {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+
+	return params, salt, key, nil
+}
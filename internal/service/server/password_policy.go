@@ -0,0 +1,231 @@
+package server
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Password policy rules reported in PasswordPolicyError.
+const (
+	PasswordRuleMinLength   = "min_length"
+	PasswordRuleCharClasses = "char_classes"
+	PasswordRuleEntropy     = "entropy"
+	PasswordRuleUsername    = "username"
+	PasswordRuleBreached    = "breached"
+)
+
+var ErrWeakPassword = errors.New("password does not satisfy the password policy")
+
+// PasswordPolicyError names the rule a rejected password failed.
+type PasswordPolicyError struct {
+	Rule   string
+	Reason string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrWeakPassword, e.Reason)
+}
+
+func (e *PasswordPolicyError) Is(target error) bool {
+	return target == ErrWeakPassword
+}
+
+// breachedPasswordChecker reports whether a password is known from data breaches.
+type breachedPasswordChecker interface {
+	IsBreached(password string) (bool, error)
+}
+
+// PasswordPolicy is checked for new passwords on registration and password change.
+// Zero values disable the corresponding rule.
+type PasswordPolicy struct {
+	MinLength      int
+	MinCharClasses int
+	MinEntropyBits float64
+	Breached       breachedPasswordChecker
+}
+
+// Check returns a *PasswordPolicyError for the first rule the password fails.
+func (p *PasswordPolicy) Check(username string, password string) error {
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		return &PasswordPolicyError{
+			Rule:   PasswordRuleMinLength,
+			Reason: fmt.Sprintf("password must be at least %d characters long", p.MinLength),
+		}
+	}
+
+	classes, poolSize := passwordCharClasses(password)
+	if classes < p.MinCharClasses {
+		return &PasswordPolicyError{
+			Rule:   PasswordRuleCharClasses,
+			Reason: fmt.Sprintf("password must contain at least %d of: lowercase, uppercase, digits, symbols", p.MinCharClasses),
+		}
+	}
+
+	if containsUsername(username, password) {
+		return &PasswordPolicyError{
+			Rule:   PasswordRuleUsername,
+			Reason: "password must not contain the username",
+		}
+	}
+
+	if p.MinEntropyBits > 0 && passwordEntropy(password, poolSize) < p.MinEntropyBits {
+		return &PasswordPolicyError{
+			Rule:   PasswordRuleEntropy,
+			Reason: "password is too predictable, use a longer password or more varied characters",
+		}
+	}
+
+	if p.Breached != nil {
+		breached, err := p.Breached.IsBreached(password)
+		if err != nil {
+			return fmt.Errorf("breached password check error: %w", err)
+		}
+		if breached {
+			return &PasswordPolicyError{
+				Rule:   PasswordRuleBreached,
+				Reason: "password appeared in a data breach, choose another one",
+			}
+		}
+	}
+
+	return nil
+}
+
+// passwordCharClasses counts the character classes used in the password and
+// the size of the alphabet they make up.
+func passwordCharClasses(password string) (int, int) {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range password {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII && unicode.IsPrint(r):
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	var classes, poolSize int
+	for _, class := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.used {
+			classes++
+			poolSize += class.size
+		}
+	}
+
+	return classes, poolSize
+}
+
+// passwordEntropy estimates the password strength in bits as log2 of the
+// alphabet size per character. Repeated characters and runs like "abc" or
+// "321" add only a fraction of a character each.
+func passwordEntropy(password string, poolSize int) float64 {
+	if poolSize == 0 {
+		return 0
+	}
+
+	var length float64
+	var prev rune = -1
+	for _, r := range password {
+		switch {
+		case r == prev:
+			length += 0.25
+		case r == prev+1 || r == prev-1:
+			length += 0.5
+		default:
+			length++
+		}
+		prev = r
+	}
+
+	return length * math.Log2(float64(poolSize))
+}
+
+func containsUsername(username string, password string) bool {
+	username = strings.ToLower(strings.TrimSpace(username))
+	if utf8.RuneCountInString(username) < 3 {
+		return false
+	}
+
+	password = strings.ToLower(password)
+	return strings.Contains(password, username) || strings.Contains(password, reverseString(username))
+}
+
+func reverseString(value string) string {
+	runes := []rune(value)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+// BreachedPasswordCorpus checks passwords against an offline copy of a
+// breached password list split by SHA-1 prefix, as served by the Have I Been
+// Pwned range API: one file per 5 hex digit prefix, named "<PREFIX>" or
+// "<PREFIX>.txt", with "<SUFFIX>:<COUNT>" lines.
+type BreachedPasswordCorpus struct {
+	dir string
+}
+
+func NewBreachedPasswordCorpus(dir string) (*BreachedPasswordCorpus, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	return &BreachedPasswordCorpus{dir: dir}, nil
+}
+
+func (c *BreachedPasswordCorpus) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	file, err := c.openRange(prefix)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineSuffix, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if strings.EqualFold(lineSuffix, suffix) {
+			return true, nil
+		}
+	}
+
+	return false, scanner.Err()
+}
+
+func (c *BreachedPasswordCorpus) openRange(prefix string) (*os.File, error) {
+	file, err := os.Open(filepath.Join(c.dir, prefix))
+	if errors.Is(err, os.ErrNotExist) {
+		file, err = os.Open(filepath.Join(c.dir, prefix+".txt"))
+	}
+	return file, err
+}
@@ -0,0 +1,187 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const defRevocationCacheTTL = time.Minute
+
+type revocationStorageRepo interface {
+	RevokeToken(ctx context.Context, tokenID string, accountID uint32, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
+	DeleteExpiredRevokedTokens(ctx context.Context) error
+	GetAccountTokenGeneration(ctx context.Context, accountID uint32) (uint32, error)
+	TouchSession(ctx context.Context, sessionID uint32) (bool, error)
+}
+
+type revocationCacheItem struct {
+	revoked   bool
+	expiresAt time.Time
+}
+
+type sessionCacheItem struct {
+	active    bool
+	expiresAt time.Time
+}
+
+type generationCacheItem struct {
+	generation uint32
+	expiresAt  time.Time
+}
+
+// RevocationService tracks revoked access token IDs, account token generations
+// and device session state. Lookups are cached in memory: revoked IDs until the
+// token itself expires, everything else for cacheTTL, so a revocation made on
+// another server instance is picked up within cacheTTL.
+type RevocationService struct {
+	store           revocationStorageRepo
+	cacheTTL        time.Duration
+	cache           map[string]revocationCacheItem
+	generationCache map[uint32]generationCacheItem
+	sessionCache    map[uint32]sessionCacheItem
+	mx              sync.RWMutex
+	logger          *zap.SugaredLogger
+}
+
+func NewRevocationService(store revocationStorageRepo, logger *zap.SugaredLogger) *RevocationService {
+	return &RevocationService{
+		store:           store,
+		cacheTTL:        defRevocationCacheTTL,
+		cache:           make(map[string]revocationCacheItem),
+		generationCache: make(map[uint32]generationCacheItem),
+		sessionCache:    make(map[uint32]sessionCacheItem),
+		logger:          logger.Named("REVOCATION"),
+	}
+}
+
+func (s *RevocationService) RevokeToken(ctx context.Context, tokenID string, accountID uint32, expiresAt time.Time) error {
+	err := s.store.RevokeToken(ctx, tokenID, accountID, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	s.mx.Lock()
+	s.cache[tokenID] = revocationCacheItem{revoked: true, expiresAt: expiresAt}
+	s.mx.Unlock()
+
+	err = s.store.DeleteExpiredRevokedTokens(ctx)
+	if err != nil {
+		s.logger.Errorf("expired revoked tokens cleanup error: %v", err)
+	}
+
+	return nil
+}
+
+func (s *RevocationService) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	now := time.Now()
+
+	s.mx.RLock()
+	item, ok := s.cache[tokenID]
+	s.mx.RUnlock()
+
+	if ok && now.Before(item.expiresAt) {
+		return item.revoked, nil
+	}
+
+	revoked, err := s.store.IsTokenRevoked(ctx, tokenID)
+	if err != nil {
+		return false, err
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.evictExpired(now)
+	s.cache[tokenID] = revocationCacheItem{revoked: revoked, expiresAt: now.Add(s.cacheTTL)}
+
+	return revoked, nil
+}
+
+// IsTokenGenerationValid reports whether a token of the given generation is
+// still current for the account. A generation newer than the cached one means
+// the cache is stale, so it is reloaded from the database.
+func (s *RevocationService) IsTokenGenerationValid(ctx context.Context, accountID uint32, generation uint32) (bool, error) {
+	now := time.Now()
+
+	s.mx.RLock()
+	item, ok := s.generationCache[accountID]
+	s.mx.RUnlock()
+
+	if !ok || now.After(item.expiresAt) || generation > item.generation {
+		current, err := s.store.GetAccountTokenGeneration(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return false, nil
+			}
+			return false, err
+		}
+		s.SetTokenGeneration(accountID, current)
+		item.generation = current
+	}
+
+	return generation == item.generation, nil
+}
+
+func (s *RevocationService) SetTokenGeneration(accountID uint32, generation uint32) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.generationCache[accountID] = generationCacheItem{generation: generation, expiresAt: time.Now().Add(s.cacheTTL)}
+}
+
+// IsSessionActive reports whether the device session has not been revoked.
+// Every cache miss also refreshes the session last seen time, so it is
+// accurate to within cacheTTL.
+func (s *RevocationService) IsSessionActive(ctx context.Context, sessionID uint32) (bool, error) {
+	now := time.Now()
+
+	s.mx.RLock()
+	item, ok := s.sessionCache[sessionID]
+	s.mx.RUnlock()
+
+	if ok && now.Before(item.expiresAt) {
+		return item.active, nil
+	}
+
+	active, err := s.store.TouchSession(ctx, sessionID)
+	if err != nil {
+		return false, err
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.evictExpired(now)
+	s.sessionCache[sessionID] = sessionCacheItem{active: active, expiresAt: now.Add(s.cacheTTL)}
+
+	return active, nil
+}
+
+func (s *RevocationService) MarkSessionRevoked(sessionID uint32) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.sessionCache[sessionID] = sessionCacheItem{active: false, expiresAt: time.Now().Add(s.cacheTTL)}
+}
+
+func (s *RevocationService) evictExpired(now time.Time) {
+	for tokenID, item := range s.cache {
+		if now.After(item.expiresAt) {
+			delete(s.cache, tokenID)
+		}
+	}
+	for accountID, item := range s.generationCache {
+		if now.After(item.expiresAt) {
+			delete(s.generationCache, accountID)
+		}
+	}
+	for sessionID, item := range s.sessionCache {
+		if now.After(item.expiresAt) {
+			delete(s.sessionCache, sessionID)
+		}
+	}
+}
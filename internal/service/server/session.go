@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	"github.com/bbquite/go-pass-keeper/internal/utils"
+)
+
+const (
+	defDeviceName     = "unknown"
+	maxSessionInfoLen = 255
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+// ListSessions returns the active device sessions of the account, marking the
+// one the request was made from as current.
+func (service *AuthService) ListSessions(ctx context.Context) ([]models.Session, error) {
+	accountID, err := service.getAccountIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := service.store.GetAccountSessions(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentID, _ := service.getSessionIDFromContext(ctx)
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentID
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession ends a device session of the account: its refresh tokens stop
+// working immediately and its access tokens within the revocation cache TTL.
+func (service *AuthService) RevokeSession(ctx context.Context, sessionID uint32) error {
+	accountID, err := service.getAccountIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = service.store.RevokeSession(ctx, accountID, sessionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+	service.revocation.MarkSessionRevoked(sessionID)
+
+	service.logger.Infof("session %d of account %d revoked", sessionID, accountID)
+	service.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditSessionRevoke, Details: fmt.Sprintf("session %d", sessionID)})
+	return nil
+}
+
+// startSession records a new device session with its own refresh token family.
+func (service *AuthService) startSession(ctx context.Context, accountID uint32, deviceName string) (models.Session, error) {
+	familyID, err := utils.GenerateRandomString(16)
+	if err != nil {
+		return models.Session{}, err
+	}
+
+	deviceName = strings.TrimSpace(deviceName)
+	if deviceName == "" {
+		deviceName = defDeviceName
+	}
+
+	return service.store.CreateSession(ctx, &models.Session{
+		AccountID:   accountID,
+		FamilyID:    familyID,
+		DeviceName:  truncateSessionInfo(deviceName),
+		PeerAddress: truncateSessionInfo(utils.GetPeerIP(ctx)),
+		UserAgent:   truncateSessionInfo(utils.GetUserAgent(ctx)),
+	})
+}
+
+// getFamilySession returns the session a refresh token family belongs to.
+// Families issued before sessions existed get a session on first refresh.
+func (service *AuthService) getFamilySession(ctx context.Context, accountID uint32, familyID string) (models.Session, error) {
+	session, err := service.store.GetSessionByFamily(ctx, familyID)
+	if err == nil {
+		return session, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return session, err
+	}
+
+	return service.store.CreateSession(ctx, &models.Session{
+		AccountID:   accountID,
+		FamilyID:    familyID,
+		DeviceName:  defDeviceName,
+		PeerAddress: truncateSessionInfo(utils.GetPeerIP(ctx)),
+		UserAgent:   truncateSessionInfo(utils.GetUserAgent(ctx)),
+	})
+}
+
+func (service *AuthService) getSessionIDFromContext(ctx context.Context) (uint32, error) {
+	sessionID, ok := ctx.Value(utils.SessionIDKey).(uint32)
+	if !ok {
+		return 0, ErrTokenRequired
+	}
+	return sessionID, nil
+}
+
+func truncateSessionInfo(value string) string {
+	runes := []rune(value)
+	if len(runes) > maxSessionInfoLen {
+		return string(runes[:maxSessionInfoLen])
+	}
+	return value
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	encryptor "github.com/bbquite/go-pass-keeper/internal/encryption"
+	"github.com/bbquite/go-pass-keeper/internal/models"
+)
+
+const (
+	maxTagsPerRecord = 32
+	maxTagLength     = 255
+	// maxSealedTagLength bounds tags sealed by the client with the vault, the
+	// client checks their plaintext against maxTagLength.
+	maxSealedTagLength = 1024
+)
+
+var ErrInvalidTags = errors.New("tags must be non-empty, up to 255 characters and 32 per record")
+
+// openTags decrypts the tags of a record. Every tag is sealed on its own, so
+// the client may seal them with the vault key as well.
+func openTags(dataKey *encryptor.Encryptor, accountID uint32, dataType models.DataTypeEnum, tags []string) ([]string, error) {
+	ad := recordAD(accountID, dataType, recordFieldTags)
+
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		plaintext, err := dataKey.DecryptWithAD(tag, ad)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, plaintext)
+	}
+	return result, nil
+}
+
+// hasTags reports whether tags contain every wanted tag.
+func hasTags(tags []string, wanted []string) bool {
+	for _, tag := range wanted {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeTags(tags []string) ([]string, error) {
+	var result []string
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		limit := maxTagLength
+		if encryptor.IsVaultValue(tag) {
+			limit = maxSealedTagLength
+		}
+		if tag == "" || len(tag) > limit {
+			return nil, ErrInvalidTags
+		}
+		if !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	if len(result) == 0 {
+		return nil, ErrInvalidTags
+	}
+	return result, nil
+}
+
+// AddTags adds the tags the record does not have yet and returns all of its
+// tags.
+func (s *DataService) AddTags(ctx context.Context, dataID uint32, tags []string) ([]string, error) {
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updateTags(ctx, dataID, func(current []string) ([]string, error) {
+		for _, tag := range tags {
+			if !slices.Contains(current, tag) {
+				current = append(current, tag)
+			}
+		}
+		if len(current) > maxTagsPerRecord {
+			return nil, ErrInvalidTags
+		}
+		return current, nil
+	})
+}
+
+// RemoveTags removes the tags from the record and returns the remaining ones.
+func (s *DataService) RemoveTags(ctx context.Context, dataID uint32, tags []string) ([]string, error) {
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updateTags(ctx, dataID, func(current []string) ([]string, error) {
+		return slices.DeleteFunc(current, func(tag string) bool {
+			return slices.Contains(tags, tag)
+		}), nil
+	})
+}
+
+// updateTags applies change to the decrypted tags of the record and stores
+// them encrypted again.
+func (s *DataService) updateTags(ctx context.Context, dataID uint32, change func(current []string) ([]string, error)) ([]string, error) {
+	accountID, dataKey, err := s.getDataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.store.GetDataByIDForUser(ctx, accountID, dataID)
+	if err != nil {
+		return nil, err
+	}
+
+	ad := recordAD(accountID, record.DataType, recordFieldTags)
+
+	var result []string
+	err = s.store.UpdateDataTags(ctx, accountID, dataID, func(stored []string) ([]string, error) {
+		current, err := openTags(dataKey, accountID, record.DataType, stored)
+		if err != nil {
+			return nil, fmt.Errorf("decryption error: %v", err)
+		}
+
+		result, err = change(current)
+		if err != nil {
+			return nil, err
+		}
+
+		sealed := make([]string, 0, len(result))
+		for _, tag := range result {
+			ciphertext, err := dataKey.EncryptWithAD(tag, ad)
+			if err != nil {
+				return nil, fmt.Errorf("encryption error: %v", err)
+			}
+			sealed = append(sealed, ciphertext)
+		}
+		return sealed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditDataTag, RecordID: dataID})
+
+	return result, nil
+}
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	encryptor "github.com/bbquite/go-pass-keeper/internal/encryption"
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	"go.uber.org/zap"
+)
+
+var ErrNotSealed = errors.New("record values and tags must be sealed with the vault")
+
+// ListTrash returns the deleted records of the account, most recently deleted
+// first.
+func (s *DataService) ListTrash(ctx context.Context) ([]models.TrashItem, error) {
+	accountID, err := s.getAccountIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.store.GetTrash(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		_, err = s.DecryptData(ctx, &items[i].Data)
+		if err != nil {
+			return nil, fmt.Errorf("decryption error for data ID %d: %v", items[i].Data.ID, err)
+		}
+	}
+
+	return items, nil
+}
+
+// RestoreData moves a deleted record out of the trash.
+func (s *DataService) RestoreData(ctx context.Context, dataID uint32) error {
+	accountID, err := s.getAccountIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.RestoreTrashedData(ctx, accountID, dataID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditDataRestore, RecordID: dataID, Details: "from trash"})
+
+	return nil
+}
+
+// PurgeData permanently deletes a record in the trash with its history.
+func (s *DataService) PurgeData(ctx context.Context, dataID uint32) error {
+	accountID, err := s.getAccountIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.PurgeTrashedData(ctx, accountID, dataID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditDataPurge, RecordID: dataID})
+
+	return nil
+}
+
+// SealTrashedData replaces the content and tags of a record in the trash with
+// values sealed by the client vault. The record stays in the trash with its
+// deletion time, and its history, which the server could read, is dropped.
+func (s *DataService) SealTrashedData(ctx context.Context, data *models.DataStoreFormat) error {
+	if !encryptor.IsVaultValue(data.DataInfo) || !encryptor.IsVaultValue(data.Meta) ||
+		slices.ContainsFunc(data.Tags, func(tag string) bool { return !encryptor.IsVaultValue(tag) }) {
+		return ErrNotSealed
+	}
+	if len(data.Tags) > 0 {
+		tags, err := normalizeTags(data.Tags)
+		if err != nil || len(tags) > maxTagsPerRecord {
+			return ErrInvalidTags
+		}
+		data.Tags = tags
+	}
+
+	accountID, dataKey, err := s.getDataKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	encryptedData, err := s.EncryptData(ctx, data)
+	if err != nil {
+		return fmt.Errorf("encryption error: %v", err)
+	}
+
+	ad := recordAD(accountID, data.DataType, recordFieldTags)
+	for i, tag := range encryptedData.Tags {
+		encryptedData.Tags[i], err = dataKey.EncryptWithAD(tag, ad)
+		if err != nil {
+			return fmt.Errorf("encryption error: %v", err)
+		}
+	}
+
+	err = s.store.SealTrashedData(ctx, accountID, encryptedData)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditDataUpdate, RecordID: data.ID, Details: "sealed in trash"})
+
+	return nil
+}
+
+type trashStorageRepo interface {
+	PurgeTrash(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// TrashPurger permanently deletes records that stayed in the trash longer
+// than the retention window.
+type TrashPurger struct {
+	store     trashStorageRepo
+	retention time.Duration
+	logger    *zap.SugaredLogger
+}
+
+func NewTrashPurger(store trashStorageRepo, retention time.Duration, logger *zap.SugaredLogger) *TrashPurger {
+	return &TrashPurger{
+		store:     store,
+		retention: retention,
+		logger:    logger.Named("TRASH"),
+	}
+}
+
+// Run purges once and then every interval until ctx is done.
+func (p *TrashPurger) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := p.store.PurgeTrash(ctx, p.retention)
+		if err != nil {
+			p.logger.Errorf("purging trash error: %v", err)
+		} else if purged > 0 {
+			p.logger.Infof("purged %d records deleted more than %s ago", purged, p.retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	encryptor "github.com/bbquite/go-pass-keeper/internal/encryption"
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	"github.com/bbquite/go-pass-keeper/internal/utils"
+	"go.uber.org/zap"
+)
+
+var ErrVaultAlreadyEnabled = errors.New("end-to-end encryption already enabled")
+
+type vaultStorageRepo interface {
+	GetAccountVault(ctx context.Context, accountID uint32) (models.VaultParams, error)
+	SetAccountVault(ctx context.Context, accountID uint32, params *models.VaultParams) (bool, error)
+}
+
+// VaultService keeps the key derivation parameters of end-to-end encrypted
+// accounts. Records of such accounts arrive already encrypted by the client
+// and the server has no way to decrypt them.
+type VaultService struct {
+	store  vaultStorageRepo
+	audit  *AuditService
+	logger *zap.SugaredLogger
+}
+
+func NewVaultService(store vaultStorageRepo, audit *AuditService, logger *zap.SugaredLogger) *VaultService {
+	return &VaultService{
+		store:  store,
+		audit:  audit,
+		logger: logger.Named("VAULT"),
+	}
+}
+
+// GetVaultParams returns empty params when the account has no vault.
+func (s *VaultService) GetVaultParams(ctx context.Context) (models.VaultParams, error) {
+	accountID, ok := ctx.Value(utils.AccountIDKey).(uint32)
+	if !ok {
+		return models.VaultParams{}, fmt.Errorf("invalid account ID format")
+	}
+
+	return s.store.GetAccountVault(ctx, accountID)
+}
+
+// SetVaultParams enables end-to-end encryption. The parameters cannot be
+// replaced afterwards, since existing records depend on them.
+func (s *VaultService) SetVaultParams(ctx context.Context, params models.VaultParams) error {
+	accountID, ok := ctx.Value(utils.AccountIDKey).(uint32)
+	if !ok {
+		return fmt.Errorf("invalid account ID format")
+	}
+
+	err := encryptor.ValidateVaultParams(params)
+	if err != nil {
+		return err
+	}
+
+	stored, err := s.store.SetAccountVault(ctx, accountID, &params)
+	if err != nil {
+		return err
+	}
+	if !stored {
+		return ErrVaultAlreadyEnabled
+	}
+
+	s.audit.Record(ctx, accountID, models.AuditEvent{EventType: models.AuditVaultEnable})
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	encryptor "github.com/bbquite/go-pass-keeper/internal/encryption"
+	"github.com/bbquite/go-pass-keeper/internal/models"
+)
+
+const cacheAdditionalData = "go-pass-keeper/client-cache"
+
+// cacheData is the part of the storage persisted to the cache file.
+type cacheData struct {
+	SavedAt      time.Time           `json:"saved_at"`
+	PairsList    []models.PairData   `json:"pairs_list"`
+	TextsList    []models.TextData   `json:"texts_list"`
+	BinariesList []models.BinaryData `json:"binary_list"`
+	CardsList    []models.CardData   `json:"cards_list"`
+	FoldersList  []models.Folder     `json:"folders_list"`
+}
+
+// OpenCache enables the on-disk cache encrypted with the passphrase and loads
+// it when the file exists. A wrong passphrase returns
+// encryptor.ErrIncorrectPassphrase and leaves the cache disabled.
+func (storage *ClientStorage) OpenCache(path string, passphrase string) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	plaintext, err := encryptor.ReadPassphraseFile(path, passphrase, cacheAdditionalData)
+	if errors.Is(err, os.ErrNotExist) {
+		storage.cachePath = path
+		storage.cachePassphrase = passphrase
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var data cacheData
+	err = json.Unmarshal(plaintext, &data)
+	if err != nil {
+		return err
+	}
+
+	storage.cachePath = path
+	storage.cachePassphrase = passphrase
+	storage.cachedAt = data.SavedAt
+	storage.PairsList = data.PairsList
+	storage.TextsList = data.TextsList
+	storage.BinariesList = data.BinariesList
+	storage.CardsList = data.CardsList
+	storage.FoldersList = data.FoldersList
+
+	return nil
+}
+
+// SaveCache writes the stored records to the cache file. It does nothing
+// when the cache is disabled.
+func (storage *ClientStorage) SaveCache() error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	if storage.cachePath == "" {
+		return nil
+	}
+
+	data := cacheData{
+		SavedAt:      time.Now(),
+		PairsList:    storage.PairsList,
+		TextsList:    storage.TextsList,
+		BinariesList: storage.BinariesList,
+		CardsList:    storage.CardsList,
+		FoldersList:  storage.FoldersList,
+	}
+
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	err = encryptor.WritePassphraseFile(storage.cachePath, storage.cachePassphrase, cacheAdditionalData, plaintext)
+	if err != nil {
+		return err
+	}
+
+	storage.cachedAt = data.SavedAt
+	return nil
+}
+
+// RemoveCache forgets the stored records and deletes the cache file, e.g. on
+// logout, so another account never sees them. The cache stays enabled.
+func (storage *ClientStorage) RemoveCache() error {
+	storage.ClearStorage()
+
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+
+	storage.cachedAt = time.Time{}
+	if storage.cachePath == "" {
+		return nil
+	}
+
+	err := os.Remove(storage.cachePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// CachedAt returns when the stored records were last synced with the server,
+// or the zero time when nothing is cached.
+func (storage *ClientStorage) CachedAt() time.Time {
+	storage.mx.RLock()
+	defer storage.mx.RUnlock()
+	return storage.cachedAt
+}
@@ -3,6 +3,7 @@ package local
 import (
 	"encoding/json"
 	"sync"
+	"time"
 
 	"github.com/bbquite/go-pass-keeper/internal/models"
 	jwttoken "github.com/bbquite/go-pass-keeper/pkg/jwt_token"
@@ -15,7 +16,12 @@ type ClientStorage struct {
 	TextsList    []models.TextData   `json:"texts_list"`
 	BinariesList []models.BinaryData `json:"binary_list"`
 	CardsList    []models.CardData   `json:"cards_list"`
+	FoldersList  []models.Folder     `json:"folders_list"`
 	mx           sync.RWMutex        `json:"-"`
+
+	cachePath       string
+	cachePassphrase string
+	cachedAt        time.Time
 }
 
 func NewClientStorage() *ClientStorage {
@@ -50,6 +56,13 @@ func (storage *ClientStorage) GetToken() string {
 	return ""
 }
 
+func (storage *ClientStorage) GetRefreshToken() string {
+	if storage.Token != nil {
+		return storage.Token.RefreshToken
+	}
+	return ""
+}
+
 func (storage *ClientStorage) AddPairs(data models.PairData) error {
 	storage.mx.Lock()
 	defer storage.mx.Unlock()
@@ -102,6 +115,19 @@ func (storage *ClientStorage) GetCards() ([]models.CardData, error) {
 	return storage.CardsList, nil
 }
 
+func (storage *ClientStorage) SetFolders(folders []models.Folder) error {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+	storage.FoldersList = folders
+	return nil
+}
+
+func (storage *ClientStorage) GetFolders() ([]models.Folder, error) {
+	storage.mx.Lock()
+	defer storage.mx.Unlock()
+	return storage.FoldersList, nil
+}
+
 func (storage *ClientStorage) ClearStorage() {
 	storage.mx.Lock()
 	defer storage.mx.Unlock()
@@ -109,6 +135,7 @@ func (storage *ClientStorage) ClearStorage() {
 	storage.TextsList = nil
 	storage.BinariesList = nil
 	storage.CardsList = nil
+	storage.FoldersList = nil
 }
 
 func (storage *ClientStorage) Debug() ([]byte, error) {
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+const accessTokenColumns = `id, account_id, name, token_hash, scopes, expires_at, created_at, last_used_at, revoked_at`
+
+func scanAccessToken(row rowScanner) (models.AccessToken, error) {
+	var token models.AccessToken
+	var scopes []byte
+
+	err := row.Scan(
+		&token.ID, &token.AccountID, &token.Name, &token.TokenHash, &scopes,
+		&token.ExpiresAt, &token.CreatedAt, &token.LastUsedAt, &token.RevokedAt,
+	)
+	if err != nil {
+		return token, err
+	}
+
+	err = json.Unmarshal(scopes, &token.Scopes)
+	if err != nil {
+		return token, fmt.Errorf("access token %d scopes: %w", token.ID, err)
+	}
+
+	return token, nil
+}
+
+func (storage *DBStorage) CreateAccessToken(ctx context.Context, token *models.AccessToken) (models.AccessToken, error) {
+	scopes, err := json.Marshal(token.Scopes)
+	if err != nil {
+		return models.AccessToken{}, err
+	}
+
+	sqlString := `
+		INSERT INTO public.access_token (account_id, name, token_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + accessTokenColumns
+
+	args := []any{token.AccountID, token.Name, token.TokenHash, string(scopes), token.ExpiresAt}
+
+	row := storage.DB.QueryRowContext(ctx, sqlString, args...)
+	return scanAccessToken(row)
+}
+
+func (storage *DBStorage) GetAccessTokenByHash(ctx context.Context, tokenHash string) (models.AccessToken, error) {
+	sqlString := `
+		SELECT ` + accessTokenColumns + `
+		FROM public.access_token
+		WHERE token_hash = $1
+	`
+
+	row := storage.DB.QueryRowContext(ctx, sqlString, tokenHash)
+	return scanAccessToken(row)
+}
+
+func (storage *DBStorage) GetAccountAccessTokens(ctx context.Context, accountID uint32) ([]models.AccessToken, error) {
+	sqlString := `
+		SELECT ` + accessTokenColumns + `
+		FROM public.access_token
+		WHERE account_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`
+
+	var result []models.AccessToken
+
+	rows, err := storage.DB.QueryContext(ctx, sqlString, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		token, err := scanAccessToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, token)
+	}
+
+	return result, rows.Err()
+}
+
+func (storage *DBStorage) TouchAccessToken(ctx context.Context, tokenID uint32) error {
+	sqlString := `
+		UPDATE public.access_token
+		SET last_used_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := storage.DB.ExecContext(ctx, sqlString, tokenID)
+	return err
+}
+
+func (storage *DBStorage) RevokeAccessToken(ctx context.Context, accountID uint32, tokenID uint32) (bool, error) {
+	sqlString := `
+		UPDATE public.access_token
+		SET revoked_at = NOW()
+		WHERE id = $1 AND account_id = $2 AND revoked_at IS NULL
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString, tokenID, accountID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
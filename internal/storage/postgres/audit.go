@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+func (storage *DBStorage) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	sqlString := `
+		INSERT INTO public.audit_event (account_id, event_type, record_id, session_id, access_token_id, peer_address, details)
+		VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, 0), NULLIF($5, 0), $6, $7)
+	`
+
+	args := []any{
+		event.AccountID, event.EventType, int64(event.RecordID), int64(event.SessionID),
+		int64(event.AccessTokenID), event.PeerAddress, event.Details,
+	}
+
+	_, err := storage.DB.ExecContext(ctx, sqlString, args...)
+	return err
+}
+
+func (storage *DBStorage) GetAuditEvents(ctx context.Context, accountID uint32, filter *models.AuditFilter) ([]models.AuditEvent, error) {
+	conditions := []string{"account_id = $1"}
+	args := []any{accountID}
+
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.From != nil {
+		addCondition("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("created_at < $%d", *filter.To)
+	}
+	if len(filter.EventTypes) > 0 {
+		var placeholders []string
+		for _, eventType := range filter.EventTypes {
+			args = append(args, eventType)
+			placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+		}
+		conditions = append(conditions, "event_type IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.BeforeID > 0 {
+		addCondition("id < $%d", int64(filter.BeforeID))
+	}
+
+	args = append(args, filter.Limit)
+
+	sqlString := fmt.Sprintf(`
+		SELECT id, account_id, event_type, COALESCE(record_id, 0), COALESCE(session_id, 0),
+			COALESCE(access_token_id, 0), peer_address, details, created_at
+		FROM public.audit_event
+		WHERE %s
+		ORDER BY id DESC
+		LIMIT $%d
+	`, strings.Join(conditions, " AND "), len(args))
+
+	var result []models.AuditEvent
+
+	rows, err := storage.DB.QueryContext(ctx, sqlString, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event models.AuditEvent
+		err := rows.Scan(
+			&event.ID, &event.AccountID, &event.EventType, &event.RecordID, &event.SessionID,
+			&event.AccessTokenID, &event.PeerAddress, &event.Details, &event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, event)
+	}
+
+	return result, rows.Err()
+}
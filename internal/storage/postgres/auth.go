@@ -3,6 +3,8 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
+
 	"github.com/bbquite/go-pass-keeper/internal/models"
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
@@ -11,13 +13,13 @@ func (storage *DBStorage) GetAccountByUsername(ctx context.Context, username str
 	var account models.Account
 
 	sqlString := `
-		SELECT id 
+		SELECT id, username, password, totp_enabled, token_generation, created_on
 		FROM public.account 
 		WHERE username = $1 
 	`
 
 	row := storage.DB.QueryRowContext(ctx, sqlString, username)
-	err := row.Scan(&account.ID)
+	err := row.Scan(&account.ID, &account.Username, &account.Password, &account.TOTPEnabled, &account.TokenGeneration, &account.CreatedOn)
 	if err != nil {
 		return account, err
 	}
@@ -25,17 +27,17 @@ func (storage *DBStorage) GetAccountByUsername(ctx context.Context, username str
 	return account, nil
 }
 
-func (storage *DBStorage) GetAccountByLoginData(ctx context.Context, username string, password string) (models.Account, error) {
+func (storage *DBStorage) GetAccountByID(ctx context.Context, accountID uint32) (models.Account, error) {
 	var account models.Account
 
 	sqlString := `
-		SELECT id 
-		FROM public.account 
-		WHERE username = $1 AND password = $2
+		SELECT id, username, password, totp_enabled, token_generation, created_on
+		FROM public.account
+		WHERE id = $1
 	`
 
-	row := storage.DB.QueryRowContext(ctx, sqlString, username, password)
-	err := row.Scan(&account.ID)
+	row := storage.DB.QueryRowContext(ctx, sqlString, accountID)
+	err := row.Scan(&account.ID, &account.Username, &account.Password, &account.TOTPEnabled, &account.TokenGeneration, &account.CreatedOn)
 	if err != nil {
 		return account, err
 	}
@@ -43,6 +45,66 @@ func (storage *DBStorage) GetAccountByLoginData(ctx context.Context, username st
 	return account, nil
 }
 
+func (storage *DBStorage) UpdateAccountPassword(ctx context.Context, accountID uint32, password string) error {
+	sqlString := `
+		UPDATE public.account
+		SET password = $1
+		WHERE id = $2
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString, password, accountID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no rows updated for account ID %d", accountID)
+	}
+
+	return nil
+}
+
+// ChangeAccountPassword stores the new password hash and bumps the token
+// generation, which invalidates every access token issued before the change.
+func (storage *DBStorage) ChangeAccountPassword(ctx context.Context, accountID uint32, password string) (uint32, error) {
+	sqlString := `
+		UPDATE public.account
+		SET password = $1, token_generation = token_generation + 1
+		WHERE id = $2
+		RETURNING token_generation
+	`
+
+	var generation uint32
+	row := storage.DB.QueryRowContext(ctx, sqlString, password, accountID)
+	err := row.Scan(&generation)
+	if err != nil {
+		return 0, err
+	}
+
+	return generation, nil
+}
+
+func (storage *DBStorage) GetAccountTokenGeneration(ctx context.Context, accountID uint32) (uint32, error) {
+	sqlString := `
+		SELECT token_generation
+		FROM public.account
+		WHERE id = $1
+	`
+
+	var generation uint32
+	row := storage.DB.QueryRowContext(ctx, sqlString, accountID)
+	err := row.Scan(&generation)
+	if err != nil {
+		return 0, err
+	}
+
+	return generation, nil
+}
+
 func (storage *DBStorage) CreateAccount(ctx context.Context, username string, password string) (uint32, error) {
 	sqlString := `
 		INSERT INTO public.account (username, password) 
@@ -63,3 +125,25 @@ func (storage *DBStorage) CreateAccount(ctx context.Context, username string, pa
 
 	return userID, nil
 }
+
+func (storage *DBStorage) DeleteAccount(ctx context.Context, accountID uint32) error {
+	sqlString := `
+		DELETE FROM public.account
+		WHERE id = $1
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString, accountID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no rows deleted for account ID %d", accountID)
+	}
+
+	return nil
+}
@@ -2,21 +2,27 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/bbquite/go-pass-keeper/internal/models"
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
+// CreateData returns sql.ErrNoRows when data.FolderID is not a folder of the
+// account.
 func (storage *DBStorage) CreateData(ctx context.Context, accountID uint32, data *models.DataStoreFormat) (models.DataStoreFormat, error) {
 	sqlString := `
-		INSERT INTO public.pass_keeper_data (data_type, data_info, meta, account_id) 
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO public.pass_keeper_data (data_type, data_info, meta, account_id, folder_id) 
+		SELECT $1, $2, $3, $4, NULLIF($5, 0)
+		WHERE $5 = 0 OR EXISTS (SELECT 1 FROM public.pass_keeper_folder WHERE id = $5 AND account_id = $4)
 		RETURNING id, uploaded_at
 	`
 
 	resultData := data
-	args := []any{data.DataType, data.DataInfo, data.Meta, accountID}
+	args := []any{data.DataType, data.DataInfo, data.Meta, accountID, int64(data.FolderID)}
 
 	row := storage.DB.QueryRowContext(ctx, sqlString, args...)
 	err := row.Scan(
@@ -30,28 +36,58 @@ func (storage *DBStorage) CreateData(ctx context.Context, accountID uint32, data
 	return *resultData, nil
 }
 
-func (storage *DBStorage) GetDataList(ctx context.Context, accountID uint32) ([]models.DataStoreFormat, error) {
-	sqlStringSelect := `
-		SELECT id, data_type, data_info, meta, uploaded_at
+func (storage *DBStorage) GetDataList(ctx context.Context, accountID uint32, filter models.DataFilter) ([]models.DataStoreFormat, error) {
+	conditions := []string{"account_id = $1", "deleted_at IS NULL"}
+	args := []any{accountID}
+
+	// The top level with its subfolders is every record.
+	if filter.FolderID != nil && !(*filter.FolderID == 0 && filter.Recursive) {
+		switch {
+		case *filter.FolderID == 0:
+			conditions = append(conditions, "folder_id IS NULL")
+		case filter.Recursive:
+			args = append(args, int64(*filter.FolderID))
+			conditions = append(conditions, fmt.Sprintf(`folder_id IN (
+				WITH RECURSIVE subfolder AS (
+					SELECT id FROM public.pass_keeper_folder WHERE id = $%[1]d AND account_id = $1
+					UNION
+					SELECT f.id FROM public.pass_keeper_folder f JOIN subfolder ON f.parent_id = subfolder.id
+				)
+				SELECT id FROM subfolder
+			)`, len(args)))
+		default:
+			args = append(args, int64(*filter.FolderID))
+			conditions = append(conditions, fmt.Sprintf("folder_id = $%d", len(args)))
+		}
+	}
+
+	sqlStringSelect := fmt.Sprintf(`
+		SELECT id, data_type, data_info, meta, COALESCE(folder_id, 0), tags, uploaded_at
 		FROM public.pass_keeper_data
-		WHERE account_id = $1;
-	`
+		WHERE %s;
+	`, strings.Join(conditions, " AND "))
 
 	var result []models.DataStoreFormat
 
-	rows, err := storage.DB.QueryContext(ctx, sqlStringSelect, accountID)
+	rows, err := storage.DB.QueryContext(ctx, sqlStringSelect, args...)
 	if err != nil {
 		return nil, err
 	}
 
 	for rows.Next() {
 		var storedItem models.DataStoreFormat
+		var tags []byte
 
-		err := rows.Scan(&storedItem.ID, &storedItem.DataType, &storedItem.DataInfo, &storedItem.Meta, &storedItem.UploadedAt)
+		err := rows.Scan(&storedItem.ID, &storedItem.DataType, &storedItem.DataInfo, &storedItem.Meta, &storedItem.FolderID, &tags, &storedItem.UploadedAt)
 		if err != nil {
 			return nil, err
 		}
 
+		storedItem.Tags, err = parseTags(tags)
+		if err != nil {
+			return nil, fmt.Errorf("data ID %d tags: %w", storedItem.ID, err)
+		}
+
 		result = append(result, storedItem)
 	}
 
@@ -64,49 +100,103 @@ func (storage *DBStorage) GetDataByIDForUser(ctx context.Context, accountID uint
 	var result models.DataStoreFormat
 
 	sqlString := `
-		SELECT id, data_type, data_info, meta, uploaded_at 
+		SELECT id, data_type, data_info, meta, COALESCE(folder_id, 0), tags, uploaded_at 
 		FROM public.pass_keeper_data 
-		WHERE id = $1 AND account_id = $2
+		WHERE id = $1 AND account_id = $2 AND deleted_at IS NULL
 	`
 
+	var tags []byte
 	row := storage.DB.QueryRowContext(ctx, sqlString, storedDataID, accountID)
-	err := row.Scan(&result.ID, &result.DataType, &result.DataInfo, &result.Meta, &result.UploadedAt)
+	err := row.Scan(&result.ID, &result.DataType, &result.DataInfo, &result.Meta, &result.FolderID, &tags, &result.UploadedAt)
 	if err != nil {
 		return result, err
 	}
 
+	result.Tags, err = parseTags(tags)
+	if err != nil {
+		return result, fmt.Errorf("data ID %d tags: %w", result.ID, err)
+	}
+
 	return result, nil
 }
 
-func (storage *DBStorage) UpdateData(ctx context.Context, accountID uint32, data *models.DataStoreFormat) error {
-	sqlString := `
-		UPDATE public.pass_keeper_data 
-		SET data_info = $1, meta = $2, uploaded_at = NOW()
-		WHERE id = $3 AND account_id = $4 AND data_type = $5
-	`
+// UpdateData replaces the record and keeps its previous version in the
+// history, pruned to the last keepVersions versions, in one transaction. A
+// keepVersions of zero or less drops the history of the record.
+func (storage *DBStorage) UpdateData(ctx context.Context, accountID uint32, data *models.DataStoreFormat, keepVersions int) error {
+	tx, err := storage.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	args := []any{data.DataInfo, data.Meta, data.ID, accountID, data.DataType}
+	err = archiveData(ctx, tx, accountID, data.ID, data.DataType, keepVersions)
+	if err != nil {
+		return err
+	}
 
-	result, err := storage.DB.ExecContext(ctx, sqlString, args...)
+	_, err = tx.ExecContext(ctx, `
+		UPDATE public.pass_keeper_data
+		SET data_info = $1, meta = $2, uploaded_at = NOW()
+		WHERE id = $3 AND account_id = $4
+	`, data.DataInfo, data.Meta, data.ID, accountID)
 	if err != nil {
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	return tx.Commit()
+}
+
+// archiveData locks the record and copies it to the history as its next
+// version, then drops versions beyond keepVersions.
+func archiveData(ctx context.Context, tx *sql.Tx, accountID uint32, dataID uint32, dataType models.DataTypeEnum, keepVersions int) error {
+	var current models.DataStoreFormat
+
+	row := tx.QueryRowContext(ctx, `
+		SELECT data_info, meta, uploaded_at
+		FROM public.pass_keeper_data
+		WHERE id = $1 AND account_id = $2 AND data_type = $3 AND deleted_at IS NULL
+		FOR UPDATE
+	`, dataID, accountID, dataType)
+	err := row.Scan(&current.DataInfo, &current.Meta, &current.UploadedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("no rows updated for data ID %d", dataID)
+	}
 	if err != nil {
 		return err
 	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("no rows updated for data ID %d", data.ID)
+
+	if keepVersions <= 0 {
+		_, err = tx.ExecContext(ctx, `DELETE FROM public.pass_keeper_data_history WHERE data_id = $1`, dataID)
+		return err
 	}
 
-	return nil
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO public.pass_keeper_data_history (data_id, account_id, version, data_type, data_info, meta, uploaded_at)
+		SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3, $4, $5, $6
+		FROM public.pass_keeper_data_history
+		WHERE data_id = $1
+	`, dataID, accountID, dataType, current.DataInfo, current.Meta, current.UploadedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM public.pass_keeper_data_history
+		WHERE data_id = $1 AND version <= (
+			SELECT MAX(version) - $2 FROM public.pass_keeper_data_history WHERE data_id = $1
+		)
+	`, dataID, keepVersions)
+	return err
 }
 
+// DeleteData moves the record to the trash. Trashed records are hidden from
+// every other query until restored or purged.
 func (storage *DBStorage) DeleteData(ctx context.Context, accountID uint32, storedDataID uint32) error {
 	sqlString := `
-		DELETE FROM public.pass_keeper_data
-		WHERE id = $1 and account_id = $2
+		UPDATE public.pass_keeper_data
+		SET deleted_at = NOW()
+		WHERE id = $1 AND account_id = $2 AND deleted_at IS NULL
 	`
 
 	result, err := storage.DB.ExecContext(ctx, sqlString, storedDataID, accountID)
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+)
+
+// GetDataVersions returns the stored versions of a record, newest first,
+// without their content.
+func (storage *DBStorage) GetDataVersions(ctx context.Context, accountID uint32, dataID uint32) ([]models.DataVersion, error) {
+	sqlString := `
+		SELECT version, data_type, uploaded_at, archived_at
+		FROM public.pass_keeper_data_history
+		WHERE data_id = $1 AND account_id = $2
+		ORDER BY version DESC
+	`
+
+	rows, err := storage.DB.QueryContext(ctx, sqlString, dataID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.DataVersion
+	for rows.Next() {
+		item := models.DataVersion{Data: models.DataStoreFormat{ID: dataID}}
+		err = rows.Scan(&item.Version, &item.Data.DataType, &item.Data.UploadedAt, &item.ArchivedAt)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// GetDataVersion returns sql.ErrNoRows for records in the trash, like the
+// other record queries.
+func (storage *DBStorage) GetDataVersion(ctx context.Context, accountID uint32, dataID uint32, version uint32) (models.DataVersion, error) {
+	result := models.DataVersion{Version: version, Data: models.DataStoreFormat{ID: dataID}}
+
+	sqlString := `
+		SELECT h.data_type, h.data_info, h.meta, h.uploaded_at, h.archived_at
+		FROM public.pass_keeper_data_history h
+		JOIN public.pass_keeper_data d ON d.id = h.data_id
+		WHERE h.data_id = $1 AND h.account_id = $2 AND h.version = $3 AND d.deleted_at IS NULL
+	`
+
+	row := storage.DB.QueryRowContext(ctx, sqlString, dataID, accountID, version)
+	err := row.Scan(&result.Data.DataType, &result.Data.DataInfo, &result.Data.Meta, &result.Data.UploadedAt, &result.ArchivedAt)
+	return result, err
+}
+
+// RestoreDataVersion makes a stored version the current one. The replaced
+// content becomes the newest version, so a restore can be undone.
+func (storage *DBStorage) RestoreDataVersion(ctx context.Context, accountID uint32, dataID uint32, version uint32, keepVersions int) error {
+	tx, err := storage.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var restored models.DataStoreFormat
+	row := tx.QueryRowContext(ctx, `
+		SELECT data_type, data_info, meta
+		FROM public.pass_keeper_data_history
+		WHERE data_id = $1 AND account_id = $2 AND version = $3
+	`, dataID, accountID, version)
+	err = row.Scan(&restored.DataType, &restored.DataInfo, &restored.Meta)
+	if err != nil {
+		return err
+	}
+
+	// The replaced content is archived even with history disabled, so the
+	// restore itself can be undone.
+	err = archiveData(ctx, tx, accountID, dataID, restored.DataType, max(keepVersions, 1))
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE public.pass_keeper_data
+		SET data_info = $1, meta = $2, uploaded_at = NOW()
+		WHERE id = $3 AND account_id = $4
+	`, restored.DataInfo, restored.Meta, dataID, accountID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetAccountDataHistory returns every stored version of the records of the
+// account, trashed ones included, by record and newest first.
+func (storage *DBStorage) GetAccountDataHistory(ctx context.Context, accountID uint32) ([]models.DataVersion, error) {
+	sqlString := `
+		SELECT data_id, version, data_type, data_info, meta, uploaded_at, archived_at
+		FROM public.pass_keeper_data_history
+		WHERE account_id = $1
+		ORDER BY data_id, version DESC
+	`
+
+	rows, err := storage.DB.QueryContext(ctx, sqlString, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.DataVersion
+	for rows.Next() {
+		var item models.DataVersion
+		err = rows.Scan(&item.Data.ID, &item.Version, &item.Data.DataType, &item.Data.DataInfo, &item.Data.Meta, &item.Data.UploadedAt, &item.ArchivedAt)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+func (storage *DBStorage) GetDataHistoryAfter(ctx context.Context, afterID uint32, limit int) ([]models.StoredRecord, error) {
+	sqlString := `
+		SELECT id, account_id, data_type, data_info, meta, uploaded_at
+		FROM public.pass_keeper_data_history
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+
+	rows, err := storage.DB.QueryContext(ctx, sqlString, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.StoredRecord
+	for rows.Next() {
+		var item models.StoredRecord
+		err = rows.Scan(&item.Record.ID, &item.AccountID, &item.Record.DataType, &item.Record.DataInfo, &item.Record.Meta, &item.Record.UploadedAt)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// ReplaceHistoryCiphertext swaps the encrypted fields of a history row. It
+// returns sql.ErrNoRows when they changed concurrently. The row ID is old.ID.
+func (storage *DBStorage) ReplaceHistoryCiphertext(ctx context.Context, old *models.DataStoreFormat, data *models.DataStoreFormat) error {
+	sqlString := `
+		UPDATE public.pass_keeper_data_history
+		SET data_info = $1, meta = $2
+		WHERE id = $3 AND data_info = $4 AND meta IS NOT DISTINCT FROM $5
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString, data.DataInfo, data.Meta, old.ID, old.DataInfo, old.Meta)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
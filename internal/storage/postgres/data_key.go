@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+)
+
+func (storage *DBStorage) GetAccountDataKey(ctx context.Context, accountID uint32) (models.AccountDataKey, error) {
+	result := models.AccountDataKey{AccountID: accountID}
+
+	sqlString := `
+		SELECT wrapped_key, legacy
+		FROM public.account_data_key
+		WHERE account_id = $1
+	`
+
+	row := storage.DB.QueryRowContext(ctx, sqlString, accountID)
+	err := row.Scan(&result.WrappedKey, &result.Legacy)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// CreateAccountDataKey stores the wrapped key unless the account already has
+// one, and returns the key that ends up stored.
+func (storage *DBStorage) CreateAccountDataKey(ctx context.Context, key *models.AccountDataKey) (models.AccountDataKey, error) {
+	sqlString := `
+		INSERT INTO public.account_data_key (account_id, wrapped_key, legacy)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (account_id) DO NOTHING
+	`
+
+	_, err := storage.DB.ExecContext(ctx, sqlString, key.AccountID, key.WrappedKey, key.Legacy)
+	if err != nil {
+		return models.AccountDataKey{}, err
+	}
+
+	return storage.GetAccountDataKey(ctx, key.AccountID)
+}
@@ -3,8 +3,10 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/bbquite/go-pass-keeper/pkg/xretry"
@@ -46,16 +48,22 @@ func (storage *DBStorage) ApplyMigrations(ctx context.Context) error {
 		return err
 	}
 
-	path := filepath.Join("migrations", "01_init.sql")
-	out, err := os.ReadFile(path)
+	paths, err := filepath.Glob(filepath.Join("migrations", "*.sql"))
 	if err != nil {
 		return err
 	}
-	sqlCreateString := string(out)
-
-	_, err = storage.DB.ExecContext(ctx, sqlCreateString)
-	if err != nil {
-		return err
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		out, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		_, err = storage.DB.ExecContext(ctx, string(out))
+		if err != nil {
+			return fmt.Errorf("migration %s error: %w", path, err)
+		}
 	}
 
 	return nil
@@ -0,0 +1,202 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+)
+
+// CreateFolder adds a folder under folder.ParentID. It returns sql.ErrNoRows
+// when the parent is not a folder of the account.
+func (storage *DBStorage) CreateFolder(ctx context.Context, accountID uint32, folder *models.Folder) (models.Folder, error) {
+	sqlString := `
+		INSERT INTO public.pass_keeper_folder (account_id, parent_id, name)
+		SELECT $1, NULLIF($2, 0), $3
+		WHERE $2 = 0 OR EXISTS (SELECT 1 FROM public.pass_keeper_folder WHERE id = $2 AND account_id = $1)
+		RETURNING id, created_at
+	`
+
+	result := *folder
+	row := storage.DB.QueryRowContext(ctx, sqlString, accountID, int64(folder.ParentID), folder.Name)
+	err := row.Scan(&result.ID, &result.CreatedAt)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (storage *DBStorage) GetFolders(ctx context.Context, accountID uint32) ([]models.Folder, error) {
+	sqlString := `
+		SELECT id, COALESCE(parent_id, 0), name, created_at
+		FROM public.pass_keeper_folder
+		WHERE account_id = $1
+		ORDER BY id
+	`
+
+	rows, err := storage.DB.QueryContext(ctx, sqlString, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.Folder
+	for rows.Next() {
+		var folder models.Folder
+		err = rows.Scan(&folder.ID, &folder.ParentID, &folder.Name, &folder.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, folder)
+	}
+
+	return result, rows.Err()
+}
+
+// RenameFolder returns sql.ErrNoRows when the folder does not exist.
+func (storage *DBStorage) RenameFolder(ctx context.Context, accountID uint32, folderID uint32, name string) error {
+	sqlString := `
+		UPDATE public.pass_keeper_folder
+		SET name = $1
+		WHERE id = $2 AND account_id = $3
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString, name, folderID, accountID)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
+
+// MoveFolder puts the folder under parentID, zero for the top level. It
+// returns sql.ErrNoRows when either folder does not exist, and false without
+// moving when parentID is the folder itself or one of its subfolders. The
+// folders of the account stay locked meanwhile, so concurrent moves cannot
+// create a cycle.
+func (storage *DBStorage) MoveFolder(ctx context.Context, accountID uint32, folderID uint32, parentID uint32) (bool, error) {
+	tx, err := storage.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM public.pass_keeper_folder WHERE account_id = $1 FOR UPDATE`, accountID)
+	if err != nil {
+		return false, err
+	}
+	folders := make(map[uint32]bool)
+	for rows.Next() {
+		var id uint32
+		err = rows.Scan(&id)
+		if err != nil {
+			rows.Close()
+			return false, err
+		}
+		folders[id] = true
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return false, err
+	}
+
+	if !folders[folderID] || (parentID != 0 && !folders[parentID]) {
+		return false, sql.ErrNoRows
+	}
+
+	if parentID != 0 {
+		var cycle bool
+		row := tx.QueryRowContext(ctx, `
+			WITH RECURSIVE subfolder AS (
+				SELECT id FROM public.pass_keeper_folder WHERE id = $1
+				UNION
+				SELECT f.id FROM public.pass_keeper_folder f JOIN subfolder ON f.parent_id = subfolder.id
+			)
+			SELECT EXISTS (SELECT 1 FROM subfolder WHERE id = $2)
+		`, folderID, parentID)
+		err = row.Scan(&cycle)
+		if err != nil {
+			return false, err
+		}
+		if cycle {
+			return false, nil
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE public.pass_keeper_folder
+		SET parent_id = NULLIF($1, 0)
+		WHERE id = $2 AND account_id = $3
+	`, int64(parentID), folderID, accountID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// DeleteFolder removes the folder and moves its records and subfolders to
+// its parent. It returns sql.ErrNoRows when the folder does not exist.
+func (storage *DBStorage) DeleteFolder(ctx context.Context, accountID uint32, folderID uint32) error {
+	tx, err := storage.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var parentID uint32
+	row := tx.QueryRowContext(ctx, `
+		SELECT COALESCE(parent_id, 0)
+		FROM public.pass_keeper_folder
+		WHERE id = $1 AND account_id = $2
+		FOR UPDATE
+	`, folderID, accountID)
+	err = row.Scan(&parentID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE public.pass_keeper_data
+		SET folder_id = NULLIF($1, 0)
+		WHERE folder_id = $2
+	`, int64(parentID), folderID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE public.pass_keeper_folder
+		SET parent_id = NULLIF($1, 0)
+		WHERE parent_id = $2
+	`, int64(parentID), folderID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM public.pass_keeper_folder WHERE id = $1`, folderID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// MoveData puts the record into folderID, zero for the top level, without
+// creating a new version. It returns sql.ErrNoRows when the record or the
+// folder does not exist.
+func (storage *DBStorage) MoveData(ctx context.Context, accountID uint32, dataID uint32, folderID uint32) error {
+	sqlString := `
+		UPDATE public.pass_keeper_data
+		SET folder_id = NULLIF($1, 0)
+		WHERE id = $2 AND account_id = $3 AND deleted_at IS NULL
+			AND ($1 = 0 OR EXISTS (SELECT 1 FROM public.pass_keeper_folder WHERE id = $1 AND account_id = $3))
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString, int64(folderID), dataID, accountID)
+	if err != nil {
+		return err
+	}
+
+	return requireRow(result)
+}
@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+)
+
+func (storage *DBStorage) GetKeyRotation(ctx context.Context, keyID string) (models.KeyRotation, error) {
+	var result models.KeyRotation
+	var finishedAt sql.NullTime
+
+	sqlString := `
+		SELECT key_id, job_version, stage, cursor_id, processed, updated, failed, started_at, finished_at
+		FROM public.key_rotation
+		WHERE key_id = $1
+	`
+
+	row := storage.DB.QueryRowContext(ctx, sqlString, keyID)
+	err := row.Scan(&result.KeyID, &result.JobVersion, &result.Stage, &result.Cursor, &result.Processed, &result.Updated, &result.Failed, &result.StartedAt, &finishedAt)
+	if err != nil {
+		return result, err
+	}
+	if finishedAt.Valid {
+		result.FinishedAt = &finishedAt.Time
+	}
+
+	return result, nil
+}
+
+func (storage *DBStorage) SaveKeyRotation(ctx context.Context, rotation *models.KeyRotation) error {
+	sqlString := `
+		INSERT INTO public.key_rotation (key_id, job_version, stage, cursor_id, processed, updated, failed, started_at, finished_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (key_id) DO UPDATE
+		SET job_version = EXCLUDED.job_version, stage = EXCLUDED.stage, cursor_id = EXCLUDED.cursor_id, processed = EXCLUDED.processed,
+			updated = EXCLUDED.updated, failed = EXCLUDED.failed, started_at = EXCLUDED.started_at,
+			finished_at = EXCLUDED.finished_at, updated_at = NOW()
+	`
+
+	args := []any{rotation.KeyID, rotation.JobVersion, rotation.Stage, rotation.Cursor, rotation.Processed, rotation.Updated, rotation.Failed, rotation.StartedAt, rotation.FinishedAt}
+
+	_, err := storage.DB.ExecContext(ctx, sqlString, args...)
+	return err
+}
+
+// HasCleanKeyRotation reports whether a run of at least jobVersion finished
+// without failed rows for any key.
+func (storage *DBStorage) HasCleanKeyRotation(ctx context.Context, jobVersion int) (bool, error) {
+	sqlString := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM public.key_rotation
+			WHERE job_version >= $1 AND finished_at IS NOT NULL AND failed = 0
+		)
+	`
+
+	var result bool
+	err := storage.DB.QueryRowContext(ctx, sqlString, jobVersion).Scan(&result)
+	return result, err
+}
+
+// CountKeyRotationRows returns the number of rows a key rotation stage walks through.
+func (storage *DBStorage) CountKeyRotationRows(ctx context.Context, stage string) (int64, error) {
+	var sqlString string
+
+	switch stage {
+	case models.KeyRotationDataKeys:
+		sqlString = `SELECT COUNT(*) FROM public.account_data_key`
+	case models.KeyRotationTOTPSecrets:
+		sqlString = `SELECT COUNT(*) FROM public.account WHERE totp_secret IS NOT NULL`
+	case models.KeyRotationRecords:
+		sqlString = `SELECT COUNT(*) FROM public.pass_keeper_data`
+	case models.KeyRotationHistory:
+		sqlString = `SELECT COUNT(*) FROM public.pass_keeper_data_history`
+	default:
+		return 0, fmt.Errorf("unknown key rotation stage %q", stage)
+	}
+
+	var count int64
+	err := storage.DB.QueryRowContext(ctx, sqlString).Scan(&count)
+	return count, err
+}
+
+func (storage *DBStorage) GetDataKeysAfter(ctx context.Context, afterAccountID uint32, limit int) ([]models.AccountDataKey, error) {
+	sqlString := `
+		SELECT account_id, wrapped_key
+		FROM public.account_data_key
+		WHERE account_id > $1
+		ORDER BY account_id
+		LIMIT $2
+	`
+
+	rows, err := storage.DB.QueryContext(ctx, sqlString, afterAccountID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.AccountDataKey
+	for rows.Next() {
+		var item models.AccountDataKey
+		err = rows.Scan(&item.AccountID, &item.WrappedKey)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// ReplaceAccountDataKey swaps the wrapped key unless it changed concurrently.
+func (storage *DBStorage) ReplaceAccountDataKey(ctx context.Context, accountID uint32, oldWrappedKey string, wrappedKey string) error {
+	sqlString := `
+		UPDATE public.account_data_key
+		SET wrapped_key = $1
+		WHERE account_id = $2 AND wrapped_key = $3
+	`
+
+	_, err := storage.DB.ExecContext(ctx, sqlString, wrappedKey, accountID, oldWrappedKey)
+	return err
+}
+
+func (storage *DBStorage) GetTOTPSecretsAfter(ctx context.Context, afterAccountID uint32, limit int) ([]models.AccountTOTP, error) {
+	sqlString := `
+		SELECT id, totp_secret, totp_enabled, totp_last_step
+		FROM public.account
+		WHERE id > $1 AND totp_secret IS NOT NULL
+		ORDER BY id
+		LIMIT $2
+	`
+
+	rows, err := storage.DB.QueryContext(ctx, sqlString, afterAccountID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.AccountTOTP
+	for rows.Next() {
+		var item models.AccountTOTP
+		err = rows.Scan(&item.AccountID, &item.Secret, &item.Enabled, &item.LastStep)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// ReplaceTOTPSecret swaps the encrypted secret unless it changed concurrently.
+func (storage *DBStorage) ReplaceTOTPSecret(ctx context.Context, accountID uint32, oldSecret string, secret string) error {
+	sqlString := `
+		UPDATE public.account
+		SET totp_secret = $1
+		WHERE id = $2 AND totp_secret = $3
+	`
+
+	_, err := storage.DB.ExecContext(ctx, sqlString, secret, accountID, oldSecret)
+	return err
+}
+
+func (storage *DBStorage) GetDataAfter(ctx context.Context, afterID uint32, limit int) ([]models.StoredRecord, error) {
+	sqlString := `
+		SELECT account_id, id, data_type, data_info, meta, uploaded_at
+		FROM public.pass_keeper_data
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+
+	rows, err := storage.DB.QueryContext(ctx, sqlString, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.StoredRecord
+	for rows.Next() {
+		var item models.StoredRecord
+		err = rows.Scan(&item.AccountID, &item.Record.ID, &item.Record.DataType, &item.Record.DataInfo, &item.Record.Meta, &item.Record.UploadedAt)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// ReplaceDataCiphertext swaps the encrypted fields of a record. It returns
+// sql.ErrNoRows when the record was updated concurrently. uploaded_at is left
+// untouched.
+func (storage *DBStorage) ReplaceDataCiphertext(ctx context.Context, old *models.DataStoreFormat, data *models.DataStoreFormat) error {
+	sqlString := `
+		UPDATE public.pass_keeper_data
+		SET data_info = $1, meta = $2
+		WHERE id = $3 AND data_info = $4 AND meta IS NOT DISTINCT FROM $5
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString, data.DataInfo, data.Meta, old.ID, old.DataInfo, old.Meta)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// UpdateLoginAttempt passes the attempt counter of the key to count, creating
+// it when missing. The row stays locked meanwhile, so parallel attempts are
+// counted one after another. When count returns true the counter is stored
+// with the failures it set and the attempt time is set to now.
+func (storage *DBStorage) UpdateLoginAttempt(ctx context.Context, keyType string, key string, count func(attempt *models.LoginAttempt) bool) error {
+	tx, err := storage.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO public.login_attempt (key_type, key, failures, last_failure_at)
+		VALUES ($1, $2, 0, NOW())
+		ON CONFLICT (key_type, key) DO NOTHING
+	`, keyType, key)
+	if err != nil {
+		return err
+	}
+
+	var attempt models.LoginAttempt
+	var sinceLast, lockedFor float64
+	row := tx.QueryRowContext(ctx, `
+		SELECT failures,
+			GREATEST(EXTRACT(EPOCH FROM NOW() - last_failure_at), 0)::float8,
+			COALESCE(GREATEST(EXTRACT(EPOCH FROM locked_until - NOW()), 0), 0)::float8,
+			COALESCE(locked_until >= last_failure_at, false)
+		FROM public.login_attempt
+		WHERE key_type = $1 AND key = $2
+		FOR UPDATE
+	`, keyType, key)
+	err = row.Scan(&attempt.Failures, &sinceLast, &lockedFor, &attempt.LockedAfterLast)
+	if err != nil {
+		return err
+	}
+	attempt.SinceLast = time.Duration(sinceLast * float64(time.Second))
+	attempt.LockedFor = time.Duration(lockedFor * float64(time.Second))
+
+	if !count(&attempt) {
+		return tx.Commit()
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE public.login_attempt
+		SET failures = $1, last_failure_at = NOW()
+		WHERE key_type = $2 AND key = $3
+	`, attempt.Failures, keyType, key)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetLoginFailures returns the attempts counted for the key, zero when there
+// are none.
+func (storage *DBStorage) GetLoginFailures(ctx context.Context, keyType string, key string) (int, error) {
+	sqlString := `
+		SELECT failures
+		FROM public.login_attempt
+		WHERE key_type = $1 AND key = $2
+	`
+
+	var failures int
+	row := storage.DB.QueryRowContext(ctx, sqlString, keyType, key)
+	err := row.Scan(&failures)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return failures, nil
+}
+
+// ReleaseLoginAttempt uncounts one attempt of the key.
+func (storage *DBStorage) ReleaseLoginAttempt(ctx context.Context, keyType string, key string) error {
+	sqlString := `
+		UPDATE public.login_attempt
+		SET failures = GREATEST(failures - 1, 0)
+		WHERE key_type = $1 AND key = $2
+	`
+
+	_, err := storage.DB.ExecContext(ctx, sqlString, keyType, key)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetLoginLock locks the key for delay from now on the database clock.
+func (storage *DBStorage) SetLoginLock(ctx context.Context, keyType string, key string, delay time.Duration) error {
+	sqlString := `
+		UPDATE public.login_attempt
+		SET locked_until = NOW() + make_interval(secs => $1)
+		WHERE key_type = $2 AND key = $3
+	`
+
+	_, err := storage.DB.ExecContext(ctx, sqlString, delay.Seconds(), keyType, key)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (storage *DBStorage) ResetLoginAttempts(ctx context.Context, keyType string, key string) (bool, error) {
+	sqlString := `
+		DELETE FROM public.login_attempt
+		WHERE key_type = $1 AND key = $2
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString, keyType, key)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+func (storage *DBStorage) CreateRefreshToken(ctx context.Context, accountID uint32, familyID string, tokenHash string, expiresAt time.Time) error {
+	sqlString := `
+		INSERT INTO public.refresh_token (account_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := storage.DB.ExecContext(ctx, sqlString, accountID, familyID, tokenHash, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (storage *DBStorage) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	var result models.RefreshToken
+
+	sqlString := `
+		SELECT id, account_id, family_id, token_hash, expires_at, used_at, revoked_at, created_on
+		FROM public.refresh_token
+		WHERE token_hash = $1
+	`
+
+	row := storage.DB.QueryRowContext(ctx, sqlString, tokenHash)
+	err := row.Scan(
+		&result.ID, &result.AccountID, &result.FamilyID, &result.TokenHash,
+		&result.ExpiresAt, &result.UsedAt, &result.RevokedAt, &result.CreatedOn,
+	)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// MarkRefreshTokenUsed marks the token as consumed. It returns false when the
+// token has already been used or revoked, which signals a reuse attempt.
+func (storage *DBStorage) MarkRefreshTokenUsed(ctx context.Context, tokenID uint32) (bool, error) {
+	sqlString := `
+		UPDATE public.refresh_token
+		SET used_at = NOW()
+		WHERE id = $1 AND used_at IS NULL AND revoked_at IS NULL
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString, tokenID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (storage *DBStorage) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	sqlString := `
+		UPDATE public.refresh_token
+		SET revoked_at = NOW()
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`
+
+	_, err := storage.DB.ExecContext(ctx, sqlString, familyID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (storage *DBStorage) RevokeAccountRefreshTokens(ctx context.Context, accountID uint32) error {
+	sqlString := `
+		UPDATE public.refresh_token
+		SET revoked_at = NOW()
+		WHERE account_id = $1 AND revoked_at IS NULL
+	`
+
+	_, err := storage.DB.ExecContext(ctx, sqlString, accountID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (storage *DBStorage) GetAccountRefreshTokens(ctx context.Context, accountID uint32) ([]models.RefreshToken, error) {
+	sqlString := `
+		SELECT id, account_id, family_id, token_hash, expires_at, used_at, revoked_at, created_on
+		FROM public.refresh_token
+		WHERE account_id = $1
+		ORDER BY created_on
+	`
+
+	var result []models.RefreshToken
+
+	rows, err := storage.DB.QueryContext(ctx, sqlString, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.RefreshToken
+
+		err := rows.Scan(
+			&item.ID, &item.AccountID, &item.FamilyID, &item.TokenHash,
+			&item.ExpiresAt, &item.UsedAt, &item.RevokedAt, &item.CreatedOn,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+func (storage *DBStorage) RevokeToken(ctx context.Context, tokenID string, accountID uint32, expiresAt time.Time) error {
+	sqlString := `
+		INSERT INTO public.revoked_token (token_id, account_id, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (token_id) DO NOTHING
+	`
+
+	_, err := storage.DB.ExecContext(ctx, sqlString, tokenID, accountID, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (storage *DBStorage) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	sqlString := `
+		SELECT EXISTS (
+			SELECT 1 FROM public.revoked_token WHERE token_id = $1
+		)
+	`
+
+	var revoked bool
+	row := storage.DB.QueryRowContext(ctx, sqlString, tokenID)
+	err := row.Scan(&revoked)
+	if err != nil {
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+func (storage *DBStorage) DeleteExpiredRevokedTokens(ctx context.Context) error {
+	sqlString := `
+		DELETE FROM public.revoked_token
+		WHERE expires_at < NOW()
+	`
+
+	_, err := storage.DB.ExecContext(ctx, sqlString)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
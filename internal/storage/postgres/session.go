@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+const sessionColumns = `id, account_id, family_id, device_name, peer_address, user_agent, created_at, last_seen_at, revoked_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSession(row rowScanner) (models.Session, error) {
+	var session models.Session
+	err := row.Scan(
+		&session.ID, &session.AccountID, &session.FamilyID, &session.DeviceName, &session.PeerAddress,
+		&session.UserAgent, &session.CreatedAt, &session.LastSeenAt, &session.RevokedAt,
+	)
+	return session, err
+}
+
+func (storage *DBStorage) CreateSession(ctx context.Context, session *models.Session) (models.Session, error) {
+	sqlString := `
+		INSERT INTO public.session (account_id, family_id, device_name, peer_address, user_agent)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + sessionColumns
+
+	args := []any{session.AccountID, session.FamilyID, session.DeviceName, session.PeerAddress, session.UserAgent}
+
+	row := storage.DB.QueryRowContext(ctx, sqlString, args...)
+	return scanSession(row)
+}
+
+func (storage *DBStorage) GetSessionByID(ctx context.Context, accountID uint32, sessionID uint32) (models.Session, error) {
+	sqlString := `
+		SELECT ` + sessionColumns + `
+		FROM public.session
+		WHERE id = $1 AND account_id = $2
+	`
+
+	row := storage.DB.QueryRowContext(ctx, sqlString, sessionID, accountID)
+	return scanSession(row)
+}
+
+func (storage *DBStorage) GetSessionByFamily(ctx context.Context, familyID string) (models.Session, error) {
+	sqlString := `
+		SELECT ` + sessionColumns + `
+		FROM public.session
+		WHERE family_id = $1
+	`
+
+	row := storage.DB.QueryRowContext(ctx, sqlString, familyID)
+	return scanSession(row)
+}
+
+func (storage *DBStorage) GetAccountSessions(ctx context.Context, accountID uint32) ([]models.Session, error) {
+	sqlString := `
+		SELECT ` + sessionColumns + `
+		FROM public.session
+		WHERE account_id = $1 AND revoked_at IS NULL
+		ORDER BY last_seen_at DESC
+	`
+
+	var result []models.Session
+
+	rows, err := storage.DB.QueryContext(ctx, sqlString, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, session)
+	}
+
+	return result, rows.Err()
+}
+
+// TouchSession updates last_seen_at of an active session. It returns false
+// when the session does not exist or has been revoked.
+func (storage *DBStorage) TouchSession(ctx context.Context, sessionID uint32) (bool, error) {
+	sqlString := `
+		UPDATE public.session
+		SET last_seen_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString, sessionID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// RevokeSession revokes the session together with its refresh token family.
+func (storage *DBStorage) RevokeSession(ctx context.Context, accountID uint32, sessionID uint32) error {
+	tx, err := storage.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var familyID string
+	row := tx.QueryRowContext(ctx, `
+		UPDATE public.session
+		SET revoked_at = NOW()
+		WHERE id = $1 AND account_id = $2 AND revoked_at IS NULL
+		RETURNING family_id
+	`, sessionID, accountID)
+	err = row.Scan(&familyID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE public.refresh_token
+		SET revoked_at = NOW()
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`, familyID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RevokeOtherSessions revokes every active session of the account except exceptSessionID.
+func (storage *DBStorage) RevokeOtherSessions(ctx context.Context, accountID uint32, exceptSessionID uint32) ([]uint32, error) {
+	sqlString := `
+		UPDATE public.session
+		SET revoked_at = NOW()
+		WHERE account_id = $1 AND id <> $2 AND revoked_at IS NULL
+		RETURNING id
+	`
+
+	var result []uint32
+
+	rows, err := storage.DB.QueryContext(ctx, sqlString, accountID, exceptSessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sessionID uint32
+		err := rows.Scan(&sessionID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, sessionID)
+	}
+
+	return result, rows.Err()
+}
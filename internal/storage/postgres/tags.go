@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// UpdateDataTags replaces the tags of the record with the result of modify,
+// which gets the stored tags. The record stays locked meanwhile, so
+// concurrent changes are not lost. It returns sql.ErrNoRows when the record
+// does not exist.
+func (storage *DBStorage) UpdateDataTags(ctx context.Context, accountID uint32, dataID uint32, modify func(tags []string) ([]string, error)) error {
+	tx, err := storage.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var raw []byte
+	row := tx.QueryRowContext(ctx, `
+		SELECT tags
+		FROM public.pass_keeper_data
+		WHERE id = $1 AND account_id = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`, dataID, accountID)
+	err = row.Scan(&raw)
+	if err != nil {
+		return err
+	}
+
+	tags, err := parseTags(raw)
+	if err != nil {
+		return fmt.Errorf("data ID %d tags: %w", dataID, err)
+	}
+
+	tags, err = modify(tags)
+	if err != nil {
+		return err
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+
+	raw, err = json.Marshal(tags)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE public.pass_keeper_data
+		SET tags = $1
+		WHERE id = $2
+	`, string(raw), dataID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func parseTags(raw []byte) ([]string, error) {
+	var tags []string
+	err := json.Unmarshal(raw, &tags)
+	return tags, err
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+func (storage *DBStorage) GetAccountTOTP(ctx context.Context, accountID uint32) (models.AccountTOTP, error) {
+	var result models.AccountTOTP
+	var secret sql.NullString
+
+	sqlString := `
+		SELECT id, totp_secret, totp_enabled, totp_last_step
+		FROM public.account
+		WHERE id = $1
+	`
+
+	row := storage.DB.QueryRowContext(ctx, sqlString, accountID)
+	err := row.Scan(&result.AccountID, &secret, &result.Enabled, &result.LastStep)
+	if err != nil {
+		return result, err
+	}
+	result.Secret = secret.String
+
+	return result, nil
+}
+
+// SetAccountTOTPSecret stores a pending secret. It does not enable 2FA until
+// EnableAccountTOTP is called after the user confirms a code.
+func (storage *DBStorage) SetAccountTOTPSecret(ctx context.Context, accountID uint32, secret string) error {
+	sqlString := `
+		UPDATE public.account
+		SET totp_secret = $1, totp_enabled = FALSE, totp_last_step = 0
+		WHERE id = $2
+	`
+
+	_, err := storage.DB.ExecContext(ctx, sqlString, secret, accountID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EnableAccountTOTP enables 2FA and replaces the account recovery codes in one transaction.
+func (storage *DBStorage) EnableAccountTOTP(ctx context.Context, accountID uint32, recoveryCodeHashes []string) error {
+	tx, err := storage.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE public.account
+		SET totp_enabled = TRUE
+		WHERE id = $1 AND totp_secret IS NOT NULL
+	`, accountID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no totp secret for account ID %d", accountID)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM public.recovery_code WHERE account_id = $1`, accountID)
+	if err != nil {
+		return err
+	}
+
+	for _, codeHash := range recoveryCodeHashes {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO public.recovery_code (account_id, code_hash)
+			VALUES ($1, $2)
+		`, accountID, codeHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (storage *DBStorage) DisableAccountTOTP(ctx context.Context, accountID uint32) error {
+	tx, err := storage.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE public.account
+		SET totp_secret = NULL, totp_enabled = FALSE, totp_last_step = 0
+		WHERE id = $1
+	`, accountID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM public.recovery_code WHERE account_id = $1`, accountID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateTOTPLastStep records the accepted time step. It returns false when the
+// step is not newer than the last accepted one, i.e. the code is being replayed.
+func (storage *DBStorage) UpdateTOTPLastStep(ctx context.Context, accountID uint32, step int64) (bool, error) {
+	sqlString := `
+		UPDATE public.account
+		SET totp_last_step = $1
+		WHERE id = $2 AND totp_last_step < $1
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString, step, accountID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// UseRecoveryCode consumes an unused recovery code. It returns false when no
+// such unused code exists.
+func (storage *DBStorage) UseRecoveryCode(ctx context.Context, accountID uint32, codeHash string) (bool, error) {
+	sqlString := `
+		UPDATE public.recovery_code
+		SET used_at = NOW()
+		WHERE id = (
+			SELECT id FROM public.recovery_code
+			WHERE account_id = $1 AND code_hash = $2 AND used_at IS NULL
+			LIMIT 1
+		)
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString, accountID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+)
+
+func (storage *DBStorage) GetTrash(ctx context.Context, accountID uint32) ([]models.TrashItem, error) {
+	sqlString := `
+		SELECT id, data_type, data_info, meta, COALESCE(folder_id, 0), tags, uploaded_at, deleted_at
+		FROM public.pass_keeper_data
+		WHERE account_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+	`
+
+	rows, err := storage.DB.QueryContext(ctx, sqlString, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.TrashItem
+	for rows.Next() {
+		var item models.TrashItem
+		var tags []byte
+		err = rows.Scan(&item.Data.ID, &item.Data.DataType, &item.Data.DataInfo, &item.Data.Meta, &item.Data.FolderID, &tags, &item.Data.UploadedAt, &item.DeletedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		item.Data.Tags, err = parseTags(tags)
+		if err != nil {
+			return nil, fmt.Errorf("data ID %d tags: %w", item.Data.ID, err)
+		}
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// RestoreTrashedData moves a record out of the trash. It returns
+// sql.ErrNoRows when the record is not in the trash.
+func (storage *DBStorage) RestoreTrashedData(ctx context.Context, accountID uint32, dataID uint32) error {
+	sqlString := `
+		UPDATE public.pass_keeper_data
+		SET deleted_at = NULL
+		WHERE id = $1 AND account_id = $2 AND deleted_at IS NOT NULL
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString, dataID, accountID)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
+
+// PurgeTrashedData permanently deletes a record in the trash with its
+// history. It returns sql.ErrNoRows when the record is not in the trash.
+func (storage *DBStorage) PurgeTrashedData(ctx context.Context, accountID uint32, dataID uint32) error {
+	sqlString := `
+		DELETE FROM public.pass_keeper_data
+		WHERE id = $1 AND account_id = $2 AND deleted_at IS NOT NULL
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString, dataID, accountID)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
+
+// SealTrashedData replaces the content and tags of a record in the trash and
+// drops its history, leaving deleted_at untouched. It returns sql.ErrNoRows
+// when the record is not in the trash.
+func (storage *DBStorage) SealTrashedData(ctx context.Context, accountID uint32, data *models.DataStoreFormat) error {
+	tags := data.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	rawTags, err := json.Marshal(tags)
+	if err != nil {
+		return err
+	}
+
+	tx, err := storage.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE public.pass_keeper_data
+		SET data_info = $1, meta = $2, tags = $3
+		WHERE id = $4 AND account_id = $5 AND data_type = $6 AND deleted_at IS NOT NULL
+	`, data.DataInfo, data.Meta, rawTags, data.ID, accountID, data.DataType)
+	if err != nil {
+		return err
+	}
+	err = requireRow(result)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM public.pass_keeper_data_history WHERE data_id = $1`, data.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// PurgeTrash permanently deletes records of every account trashed longer than
+// retention ago and returns how many were deleted.
+func (storage *DBStorage) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	sqlString := `
+		DELETE FROM public.pass_keeper_data
+		WHERE deleted_at IS NOT NULL AND deleted_at < NOW() - make_interval(secs => $1)
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString, retention.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func requireRow(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bbquite/go-pass-keeper/internal/models"
+)
+
+func (storage *DBStorage) GetAccountVault(ctx context.Context, accountID uint32) (models.VaultParams, error) {
+	var result models.VaultParams
+	var salt, verifier sql.NullString
+
+	sqlString := `
+		SELECT vault_salt, vault_memory, vault_iterations, vault_parallelism, vault_verifier
+		FROM public.account
+		WHERE id = $1
+	`
+
+	row := storage.DB.QueryRowContext(ctx, sqlString, accountID)
+	err := row.Scan(&salt, &result.Memory, &result.Iterations, &result.Parallelism, &verifier)
+	if err != nil {
+		return result, err
+	}
+	result.Salt = salt.String
+	result.Verifier = verifier.String
+
+	return result, nil
+}
+
+// SetAccountVault stores the vault parameters once. It reports false when the
+// account already has a vault.
+func (storage *DBStorage) SetAccountVault(ctx context.Context, accountID uint32, params *models.VaultParams) (bool, error) {
+	sqlString := `
+		UPDATE public.account
+		SET vault_salt = $1, vault_memory = $2, vault_iterations = $3, vault_parallelism = $4, vault_verifier = $5
+		WHERE id = $6 AND vault_salt IS NULL
+	`
+
+	result, err := storage.DB.ExecContext(ctx, sqlString,
+		params.Salt, params.Memory, params.Iterations, params.Parallelism, params.Verifier, accountID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
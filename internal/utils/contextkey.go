@@ -2,4 +2,10 @@ package utils
 
 type contextKey string
 
-const AccountIDKey contextKey = "userID"
+const (
+	AccountIDKey     contextKey = "userID"
+	TokenIDKey       contextKey = "tokenID"
+	TokenExpiryKey   contextKey = "tokenExpiry"
+	SessionIDKey     contextKey = "sessionID"
+	AccessTokenIDKey contextKey = "accessTokenID"
+)
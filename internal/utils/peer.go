@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// GetPeerIP returns the remote IP address of the gRPC caller, or an empty
+// string when it is unknown.
+func GetPeerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// GetUserAgent returns the user agent sent by the gRPC caller, or an empty
+// string when it is unknown.
+func GetUserAgent(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get("user-agent")
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
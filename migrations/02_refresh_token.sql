@@ -0,0 +1,17 @@
+DO $$
+    BEGIN
+        CREATE TABLE IF NOT EXISTS public.refresh_token(
+            id serial PRIMARY KEY,
+            account_id integer NOT NULL,
+            family_id VARCHAR (64) NOT NULL,
+            token_hash VARCHAR (255) UNIQUE NOT NULL,
+            expires_at TIMESTAMP NOT NULL,
+            used_at TIMESTAMP,
+            revoked_at TIMESTAMP,
+            created_on TIMESTAMP NOT NULL default CURRENT_TIMESTAMP,
+            FOREIGN KEY (account_id) REFERENCES public.account (id) ON DELETE CASCADE
+        );
+
+        CREATE INDEX IF NOT EXISTS refresh_token_family_idx ON public.refresh_token (family_id);
+    END
+$$;
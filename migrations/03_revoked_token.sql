@@ -0,0 +1,11 @@
+DO $$
+    BEGIN
+        CREATE TABLE IF NOT EXISTS public.revoked_token(
+            token_id VARCHAR (64) PRIMARY KEY,
+            account_id integer NOT NULL,
+            expires_at TIMESTAMP NOT NULL,
+            revoked_at TIMESTAMP NOT NULL default CURRENT_TIMESTAMP,
+            FOREIGN KEY (account_id) REFERENCES public.account (id) ON DELETE CASCADE
+        );
+    END
+$$;
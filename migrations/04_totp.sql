@@ -0,0 +1,16 @@
+DO $$
+    BEGIN
+        ALTER TABLE public.account ADD COLUMN IF NOT EXISTS totp_secret TEXT;
+        ALTER TABLE public.account ADD COLUMN IF NOT EXISTS totp_enabled BOOLEAN NOT NULL default FALSE;
+        ALTER TABLE public.account ADD COLUMN IF NOT EXISTS totp_last_step BIGINT NOT NULL default 0;
+
+        CREATE TABLE IF NOT EXISTS public.recovery_code(
+            id serial PRIMARY KEY,
+            account_id integer NOT NULL,
+            code_hash VARCHAR (255) NOT NULL,
+            used_at TIMESTAMP,
+            created_on TIMESTAMP NOT NULL default CURRENT_TIMESTAMP,
+            FOREIGN KEY (account_id) REFERENCES public.account (id) ON DELETE CASCADE
+        );
+    END
+$$;
@@ -0,0 +1,12 @@
+DO $$
+    BEGIN
+        CREATE TABLE IF NOT EXISTS public.login_attempt(
+            key_type VARCHAR (16) NOT NULL,
+            key VARCHAR (255) NOT NULL,
+            failures integer NOT NULL default 0,
+            last_failure_at TIMESTAMP NOT NULL default CURRENT_TIMESTAMP,
+            locked_until TIMESTAMP,
+            PRIMARY KEY (key_type, key)
+        );
+    END
+$$;
@@ -0,0 +1,5 @@
+DO $$
+    BEGIN
+        ALTER TABLE public.account ADD COLUMN IF NOT EXISTS token_generation integer NOT NULL default 0;
+    END
+$$;
@@ -0,0 +1,16 @@
+DO $$
+    BEGIN
+        CREATE TABLE IF NOT EXISTS public.session(
+            id serial PRIMARY KEY,
+            account_id integer NOT NULL,
+            family_id VARCHAR (64) UNIQUE NOT NULL,
+            device_name VARCHAR (255) NOT NULL default '',
+            peer_address VARCHAR (255) NOT NULL default '',
+            user_agent VARCHAR (255) NOT NULL default '',
+            created_at TIMESTAMP NOT NULL default CURRENT_TIMESTAMP,
+            last_seen_at TIMESTAMP NOT NULL default CURRENT_TIMESTAMP,
+            revoked_at TIMESTAMP,
+            FOREIGN KEY (account_id) REFERENCES public.account (id) ON DELETE CASCADE
+        );
+    END
+$$;
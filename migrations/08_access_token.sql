@@ -0,0 +1,16 @@
+DO $$
+    BEGIN
+        CREATE TABLE IF NOT EXISTS public.access_token(
+            id serial PRIMARY KEY,
+            account_id integer NOT NULL,
+            name VARCHAR (255) NOT NULL,
+            token_hash VARCHAR (255) UNIQUE NOT NULL,
+            scopes JSONB NOT NULL default '{}',
+            expires_at TIMESTAMP NOT NULL,
+            created_at TIMESTAMP NOT NULL default CURRENT_TIMESTAMP,
+            last_used_at TIMESTAMP,
+            revoked_at TIMESTAMP,
+            FOREIGN KEY (account_id) REFERENCES public.account (id) ON DELETE CASCADE
+        );
+    END
+$$;
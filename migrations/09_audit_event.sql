@@ -0,0 +1,18 @@
+DO $$
+    BEGIN
+        CREATE TABLE IF NOT EXISTS public.audit_event(
+            id bigserial PRIMARY KEY,
+            account_id integer NOT NULL,
+            event_type VARCHAR (64) NOT NULL,
+            record_id integer,
+            session_id integer,
+            access_token_id integer,
+            peer_address VARCHAR (255) NOT NULL default '',
+            details VARCHAR (255) NOT NULL default '',
+            created_at TIMESTAMP NOT NULL default CURRENT_TIMESTAMP,
+            FOREIGN KEY (account_id) REFERENCES public.account (id) ON DELETE CASCADE
+        );
+
+        CREATE INDEX IF NOT EXISTS audit_event_account_idx ON public.audit_event (account_id, id DESC);
+    END
+$$;
@@ -0,0 +1,12 @@
+DO $$
+    BEGIN
+        ALTER TABLE public.account ADD COLUMN IF NOT EXISTS vault_salt TEXT;
+        ALTER TABLE public.account ADD COLUMN IF NOT EXISTS vault_memory INTEGER NOT NULL default 0;
+        ALTER TABLE public.account ADD COLUMN IF NOT EXISTS vault_iterations INTEGER NOT NULL default 0;
+        ALTER TABLE public.account ADD COLUMN IF NOT EXISTS vault_parallelism SMALLINT NOT NULL default 0;
+        ALTER TABLE public.account ADD COLUMN IF NOT EXISTS vault_verifier TEXT;
+
+        -- client side ciphertext does not fit the old limit
+        ALTER TABLE public.pass_keeper_data ALTER COLUMN meta TYPE TEXT;
+    END
+$$;
@@ -0,0 +1,10 @@
+DO $$
+    BEGIN
+        CREATE TABLE IF NOT EXISTS public.account_data_key(
+            account_id integer PRIMARY KEY,
+            wrapped_key TEXT NOT NULL,
+            created_on TIMESTAMP NOT NULL default CURRENT_TIMESTAMP,
+            FOREIGN KEY (account_id) REFERENCES public.account (id) ON DELETE CASCADE
+        );
+    END
+$$;
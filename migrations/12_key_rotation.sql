@@ -0,0 +1,15 @@
+DO $$
+    BEGIN
+        CREATE TABLE IF NOT EXISTS public.key_rotation(
+            key_id VARCHAR (255) PRIMARY KEY,
+            stage VARCHAR (32) NOT NULL,
+            cursor_id integer NOT NULL default 0,
+            processed BIGINT NOT NULL default 0,
+            updated BIGINT NOT NULL default 0,
+            failed BIGINT NOT NULL default 0,
+            started_at TIMESTAMP NOT NULL default CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP NOT NULL default CURRENT_TIMESTAMP,
+            finished_at TIMESTAMP
+        );
+    END
+$$;
@@ -0,0 +1,5 @@
+DO $$
+    BEGIN
+        ALTER TABLE public.key_rotation ADD COLUMN IF NOT EXISTS job_version integer NOT NULL default 1;
+    END
+$$;
@@ -0,0 +1,18 @@
+DO $$
+    BEGIN
+        CREATE TABLE IF NOT EXISTS public.pass_keeper_data_history(
+            id serial PRIMARY KEY,
+            data_id integer NOT NULL,
+            account_id integer NOT NULL,
+            version integer NOT NULL,
+            data_type datatype NOT NULL,
+            data_info TEXT NOT NULL,
+            meta TEXT,
+            uploaded_at TIMESTAMP NOT NULL,
+            archived_at TIMESTAMP NOT NULL default CURRENT_TIMESTAMP,
+            UNIQUE (data_id, version),
+            FOREIGN KEY (data_id) REFERENCES public.pass_keeper_data (id) ON DELETE CASCADE,
+            FOREIGN KEY (account_id) REFERENCES public.account (id) ON DELETE CASCADE
+        );
+    END
+$$;
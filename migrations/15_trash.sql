@@ -0,0 +1,7 @@
+DO $$
+    BEGIN
+        ALTER TABLE public.pass_keeper_data ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+        CREATE INDEX IF NOT EXISTS pass_keeper_data_deleted_at_idx ON public.pass_keeper_data (deleted_at) WHERE deleted_at IS NOT NULL;
+    END
+$$;
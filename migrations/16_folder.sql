@@ -0,0 +1,20 @@
+DO $$
+    BEGIN
+        CREATE TABLE IF NOT EXISTS public.pass_keeper_folder(
+            id serial PRIMARY KEY,
+            account_id integer NOT NULL,
+            parent_id integer,
+            name TEXT NOT NULL,
+            created_at TIMESTAMP NOT NULL default CURRENT_TIMESTAMP,
+            FOREIGN KEY (account_id) REFERENCES public.account (id) ON DELETE CASCADE,
+            FOREIGN KEY (parent_id) REFERENCES public.pass_keeper_folder (id) ON DELETE SET NULL
+        );
+
+        CREATE INDEX IF NOT EXISTS pass_keeper_folder_account_id_idx ON public.pass_keeper_folder (account_id);
+
+        ALTER TABLE public.pass_keeper_data ADD COLUMN IF NOT EXISTS folder_id integer
+            REFERENCES public.pass_keeper_folder (id) ON DELETE SET NULL;
+
+        CREATE INDEX IF NOT EXISTS pass_keeper_data_folder_id_idx ON public.pass_keeper_data (folder_id);
+    END
+$$;
@@ -0,0 +1,5 @@
+DO $$
+    BEGIN
+        ALTER TABLE public.pass_keeper_data ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL default '[]';
+    END
+$$;
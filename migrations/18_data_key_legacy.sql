@@ -0,0 +1,8 @@
+DO $$
+    BEGIN
+        -- existing keys may belong to accounts whose old records are still
+        -- encrypted with the master key
+        ALTER TABLE public.account_data_key ADD COLUMN IF NOT EXISTS legacy BOOLEAN NOT NULL default true;
+        ALTER TABLE public.account_data_key ALTER COLUMN legacy SET DEFAULT false;
+    END
+$$;
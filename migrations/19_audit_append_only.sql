@@ -0,0 +1,29 @@
+DO $$
+    BEGIN
+        -- Updates and deletes of audit events fail instead of being silently
+        -- dropped. Rows may only go when their account is deleted, through the
+        -- cascade of the account foreign key.
+        CREATE OR REPLACE FUNCTION public.audit_event_append_only() RETURNS trigger AS $fn$
+            BEGIN
+                IF TG_OP = 'DELETE' THEN
+                    IF NOT EXISTS (SELECT 1 FROM public.account WHERE id = OLD.account_id) THEN
+                        RETURN OLD;
+                    END IF;
+                END IF;
+                RAISE EXCEPTION 'audit_event is append-only, % is not allowed', TG_OP;
+            END
+        $fn$ LANGUAGE plpgsql;
+
+        -- earlier databases silently dropped updates with a rule
+        DROP RULE IF EXISTS audit_event_no_update ON public.audit_event;
+        DROP TRIGGER IF EXISTS audit_event_append_only ON public.audit_event;
+        CREATE TRIGGER audit_event_append_only
+            BEFORE UPDATE OR DELETE ON public.audit_event
+            FOR EACH ROW EXECUTE FUNCTION public.audit_event_append_only();
+
+        DROP TRIGGER IF EXISTS audit_event_no_truncate ON public.audit_event;
+        CREATE TRIGGER audit_event_no_truncate
+            BEFORE TRUNCATE ON public.audit_event
+            FOR EACH STATEMENT EXECUTE FUNCTION public.audit_event_append_only();
+    END
+$$;
@@ -1,77 +1,159 @@
 package jwttoken
 
 import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 )
 
+var (
+	ErrInvalidIssuer   = errors.New("token issuer is not accepted")
+	ErrInvalidAudience = errors.New("token audience is not accepted")
+)
+
+const (
+	refreshTokenSize = 32
+	tokenIDSize      = 16
+
+	// MfaTokenExpiry is the lifetime of a token issued between the password
+	// step and the second factor step of a login.
+	MfaTokenExpiry = 5 * time.Minute
+)
+
 type JWTManager struct {
-	ExpiryHour time.Duration
-	Secret     string
+	ExpiryHour    time.Duration
+	RefreshExpiry time.Duration
+	Issuer        string
+	Audience      string
+	keyring       *Keyring
 }
 
-func NewJWTTokenManager(exp time.Duration, secret string) *JWTManager {
+func NewJWTTokenManager(keyring *Keyring, exp time.Duration, refreshExp time.Duration, issuer string, audience string) *JWTManager {
 	return &JWTManager{
-		ExpiryHour: exp,
-		Secret:     secret,
+		ExpiryHour:    exp,
+		RefreshExpiry: refreshExp,
+		Issuer:        issuer,
+		Audience:      audience,
+		keyring:       keyring,
 	}
 }
 
 type JWT struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	MfaToken     string `json:"-"`
 }
 
 type Claims struct {
 	jwt.RegisteredClaims
-	UserID uint32
+	UserID     uint32
+	Generation uint32 `json:"gen,omitempty"`
+	SessionID  uint32 `json:"sid,omitempty"`
+	MfaPending bool   `json:"mfa_pending,omitempty"`
+}
+
+// CreateAccessToken issues an access token bound to the account token generation
+// and to a device session. Tokens from an older generation are rejected once the
+// generation is bumped, and tokens of a revoked session once it is revoked.
+func (tm *JWTManager) CreateAccessToken(userid uint32, generation uint32, sessionID uint32) (accessToken string, err error) {
+	return tm.createToken(userid, generation, sessionID, tm.ExpiryHour, false)
+}
+
+// CreateMfaToken issues a short-lived token that only proves the password step
+// of a login. It is rejected everywhere an access token is expected.
+func (tm *JWTManager) CreateMfaToken(userid uint32) (mfaToken string, err error) {
+	return tm.createToken(userid, 0, 0, MfaTokenExpiry, true)
 }
 
-func (tm *JWTManager) CreateAccessToken(userid uint32) (accessToken string, err error) {
+func (tm *JWTManager) createToken(userid uint32, generation uint32, sessionID uint32, expiry time.Duration, mfaPending bool) (string, error) {
+	tokenID, err := generateTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
 	claims := &Claims{
-		UserID: userid,
+		UserID:     userid,
+		Generation: generation,
+		SessionID:  sessionID,
+		MfaPending: mfaPending,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tm.ExpiryHour)),
+			ID:        tokenID,
+			Issuer:    tm.Issuer,
+			Audience:  jwt.ClaimStrings{tm.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	t, err := token.SignedString([]byte(tm.Secret))
-	if err != nil {
-		return "", err
+
+	return tm.keyring.sign(claims)
+}
+
+// CreateRefreshToken returns an opaque random refresh token and its expiry time.
+// Refresh tokens are not JWTs: the server stores only their hash.
+func (tm *JWTManager) CreateRefreshToken() (refreshToken string, expiresAt time.Time, err error) {
+	buf := make([]byte, refreshTokenSize)
+	if _, err = rand.Read(buf); err != nil {
+		return "", time.Time{}, err
 	}
-	return t, err
+	return base64.RawURLEncoding.EncodeToString(buf), time.Now().Add(tm.RefreshExpiry), nil
 }
 
+// IsAuthorized reports whether the token is signed by a key of the keyring,
+// not expired and issued by the configured issuer for the configured audience.
 func (tm *JWTManager) IsAuthorized(requestToken string) (bool, error) {
-	_, err := jwt.Parse(requestToken, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(tm.Secret), nil
-	})
+	_, err := tm.parse(requestToken)
 	if err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
-func (tm *JWTManager) ExtractIDFromToken(requestToken string) (uint32, error) {
-	token, err := jwt.ParseWithClaims(requestToken, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(tm.Secret), nil
-	})
+// ExtractClaimsFromToken validates the token and returns its claims.
+func (tm *JWTManager) ExtractClaimsFromToken(requestToken string) (*Claims, error) {
+	return tm.parse(requestToken)
+}
 
+func (tm *JWTManager) ExtractIDFromToken(requestToken string) (uint32, error) {
+	claims, err := tm.parse(requestToken)
 	if err != nil {
 		return 0, err
 	}
+	return claims.UserID, nil
+}
+
+func (tm *JWTManager) parse(requestToken string) (*Claims, error) {
+	parser := jwt.NewParser(jwt.WithValidMethods(tm.keyring.methods))
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		userID := claims.UserID
-		return userID, nil
+	token, err := parser.ParseWithClaims(requestToken, &Claims{}, tm.keyring.keyFunc)
+	if err != nil {
+		return nil, err
 	}
 
-	return 0, fmt.Errorf("invalid Token")
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("invalid Token")
+	}
+
+	if !claims.VerifyIssuer(tm.Issuer, true) {
+		return nil, ErrInvalidIssuer
+	}
+	if !claims.VerifyAudience(tm.Audience, true) {
+		return nil, ErrInvalidAudience
+	}
+
+	return claims, nil
+}
+
+func generateTokenID() (string, error) {
+	buf := make([]byte, tokenIDSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
 }
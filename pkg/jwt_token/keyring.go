@@ -0,0 +1,161 @@
+package jwttoken
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// SecretKeyID is the kid of the HMAC key derived from a shared secret.
+const SecretKeyID = "secret"
+
+var (
+	ErrNoSigningKey  = errors.New("no signing key configured")
+	ErrUnknownKeyID  = errors.New("unknown signing key id")
+	ErrVerifyOnlyKey = errors.New("active key has no private part")
+)
+
+// SigningKey is a single key of the keyring. A key loaded from a public key
+// file can only verify tokens, which allows accepting tokens of a retired key
+// without being able to sign with it.
+type SigningKey struct {
+	ID        string
+	Method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+func NewHMACKey(id string, secret []byte) SigningKey {
+	return SigningKey{
+		ID:        id,
+		Method:    jwt.SigningMethodHS256,
+		signKey:   secret,
+		verifyKey: secret,
+	}
+}
+
+// LoadPEMKey reads an Ed25519 (EdDSA) or P-256 (ES256) key from a PEM file.
+// Private keys may be PKCS#8 or SEC 1 encoded, public keys PKIX encoded.
+func LoadPEMKey(id string, path string) (SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return SigningKey{}, fmt.Errorf("key %q: no PEM data in %s", id, path)
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return SigningKey{}, fmt.Errorf("key %q: unsupported PEM block %q", id, block.Type)
+	}
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("key %q: %w", id, err)
+	}
+
+	return newAsymmetricKey(id, key)
+}
+
+func newAsymmetricKey(id string, key interface{}) (SigningKey, error) {
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return SigningKey{ID: id, Method: jwt.SigningMethodEdDSA, signKey: k, verifyKey: k.Public()}, nil
+	case ed25519.PublicKey:
+		return SigningKey{ID: id, Method: jwt.SigningMethodEdDSA, verifyKey: k}, nil
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return SigningKey{}, fmt.Errorf("key %q: only P-256 ECDSA keys are supported", id)
+		}
+		return SigningKey{ID: id, Method: jwt.SigningMethodES256, signKey: k, verifyKey: &k.PublicKey}, nil
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return SigningKey{}, fmt.Errorf("key %q: only P-256 ECDSA keys are supported", id)
+		}
+		return SigningKey{ID: id, Method: jwt.SigningMethodES256, verifyKey: k}, nil
+	}
+
+	return SigningKey{}, fmt.Errorf("key %q: unsupported key type %T", id, key)
+}
+
+// Keyring holds every key tokens may be verified with. New tokens are signed
+// with the active key only, so a key can be rotated by adding the new one,
+// switching the active kid and removing the old key once its tokens expired.
+type Keyring struct {
+	active  string
+	keys    map[string]SigningKey
+	methods []string
+}
+
+func NewKeyring(activeID string, keys ...SigningKey) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoSigningKey
+	}
+
+	kr := &Keyring{
+		active: activeID,
+		keys:   make(map[string]SigningKey, len(keys)),
+	}
+
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, fmt.Errorf("signing key without id")
+		}
+		if _, ok := kr.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate signing key id %q", key.ID)
+		}
+		kr.keys[key.ID] = key
+		kr.methods = append(kr.methods, key.Method.Alg())
+	}
+
+	active, ok := kr.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("%w: active key %q", ErrUnknownKeyID, activeID)
+	}
+	if active.signKey == nil {
+		return nil, fmt.Errorf("%w: %q", ErrVerifyOnlyKey, activeID)
+	}
+
+	return kr, nil
+}
+
+func (kr *Keyring) sign(claims jwt.Claims) (string, error) {
+	key := kr.keys[kr.active]
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.signKey)
+}
+
+// keyFunc selects the verification key by the token kid header and makes sure
+// the token algorithm matches the key, so a public key is never used as an
+// HMAC secret.
+func (kr *Keyring) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := kr.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, kid)
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.verifyKey, nil
+}